@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/unomns/findpath/pkg/findpath"
 )
@@ -11,6 +14,13 @@ func main() {
 	file := flag.String("file", "map.example.json", "Path to the map JSON")
 	algorithm := flag.String("algo", "a", "Path finding algorithm (a*, etc)")
 	debugMode := flag.Bool("debug", false, "Use debug mode for extended logs")
+	timeout := flag.Duration("timeout", 0, "Stop searching after this duration (0 - no limit)")
+	maxExpansions := flag.Int("max-expansions", 0, "Max nodes expanded per player (0 - no limit)")
+	routes := flag.Int("routes", 1, "Number of alternative routes per player (Yen's k-shortest paths)")
+	exportNavmesh := flag.String("export-navmesh", "", "Convert the grid map to a navmesh JSON file and exit")
+	navmesh := flag.String("navmesh", "", "Path to a navmesh JSON, searched instead of the grid map")
+	obstacles := flag.String("obstacles", "", "Path to a JSON with obstacle polygons and players, searched with a visibility graph")
+	maxOverlap := flag.Float64("max-overlap", 0, "Skip routes sharing more than this share of cells with a better one (0 - off)")
 
 	flag.Parse()
 
@@ -25,7 +35,32 @@ func main() {
 		return
 	}
 
-	paths, err := service.GetPathFromFile(*file)
+	service.SetMaxExpansions(*maxExpansions)
+	service.SetKShortestPaths(*routes, *maxOverlap)
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	if *exportNavmesh != "" {
+		exportNavMesh(*file, *exportNavmesh)
+		return
+	}
+
+	if *navmesh != "" {
+		printNavPaths(service.GetPathFromNavMeshFile(ctx, *navmesh))
+		return
+	}
+
+	if *obstacles != "" {
+		printNavPaths(service.GetPathAroundObstaclesFile(ctx, *obstacles))
+		return
+	}
+
+	paths, err := service.GetPathFromFileContext(ctx, *file)
 
 	if err != nil {
 		fmt.Printf("Error! %v\n", err)
@@ -33,6 +68,73 @@ func main() {
 	}
 
 	for i, path := range paths {
-		fmt.Printf("Result #%d: %v\n\n", i, path)
+		fmt.Printf("Result #%d: player %s, %s\n", i, path.PlayerID, path.StopReason)
+
+		if path.Message != "" {
+			fmt.Printf("  %s: %s\n", path.Status, path.Message)
+		}
+
+		if s := path.Stats; s != nil {
+			fmt.Printf(
+				"  cost: %d, expanded: %d, generated: %d, peak open: %d, took: %v\n",
+				s.Cost, s.NodesExpanded, s.NodesGenerated, s.PeakOpen, s.Duration,
+			)
+		}
+
+		printSteps(path.Steps)
+
+		for k, r := range path.Routes {
+			fmt.Printf("  route #%d, cost %d:\n", k, r.Cost)
+			printSteps(r.Steps)
+		}
+		fmt.Println()
 	}
 }
+
+func printSteps(steps []*findpath.Node) {
+	for _, n := range steps {
+		if n.Z != 0 {
+			fmt.Printf("  [%d %d z%d]", n.Y, n.X, n.Z)
+			continue
+		}
+
+		fmt.Printf("  [%d %d]", n.Y, n.X)
+	}
+	fmt.Println()
+}
+
+func printNavPaths(paths []*findpath.NavPath, err error) {
+	if err != nil {
+		fmt.Printf("Error! %v\n", err)
+		return
+	}
+
+	for i, path := range paths {
+		fmt.Printf("Result #%d: player %s, %s, length %.2f\n", i, path.PlayerID, path.StopReason, path.Length)
+		for _, p := range path.Points {
+			fmt.Printf("  (%.2f %.2f)", p.X, p.Y)
+		}
+		fmt.Printf("\n\n")
+	}
+}
+
+func exportNavMesh(mapFile string, out string) {
+	mesh, err := findpath.NavMeshFromFile(mapFile, 0)
+	if err != nil {
+		fmt.Printf("Error! %v\n", err)
+		return
+	}
+
+	data, err := json.MarshalIndent(mesh, "", "  ")
+	if err != nil {
+		fmt.Printf("Error! %v\n", err)
+		return
+	}
+
+	if err = os.WriteFile(out, data, 0o644); err != nil {
+		fmt.Printf("Error! %v\n", err)
+		return
+	}
+
+	fmt.Printf("Navmesh with %d polygons written to %s\n", mesh.PolygonCount(), out)
+}
@@ -10,12 +10,21 @@ import (
 	"syscall"
 
 	app_grpc "github.com/unomns/findpath/internal/grpc"
+	"github.com/unomns/findpath/pkg/findpath"
 	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
 	"google.golang.org/grpc"
 )
 
 func main() {
 	port := flag.String("port", "50051", "Port for gRPC server")
+	workers := flag.Int("workers", 0, "Searches running at once (0 - GOMAXPROCS)")
+	queueDepth := flag.Int("queue-depth", 64, "Requests waiting for workers before new ones get ResourceExhausted (0 - no limit)")
+	cacheSize := flag.Int("cache-size", 0, "Paths kept in the cache (0 - no cache)")
+	cacheTTL := flag.Duration("cache-ttl", 0, "How long cached paths stay valid (0 - until evicted)")
+	maxMaps := flag.Int("max-maps", 100, "Maps kept by UploadMap, least recently used ones are evicted (0 - no limit)")
+	maxMapCells := flag.Int64("max-map-cells", 64<<20, "Cells of all uploaded maps together (0 - no limit)")
+	streamQueries := flag.Int("stream-queries", 16, "Queries of one StreamPaths stream searched at once")
 	flag.Parse()
 
 	addr := fmt.Sprintf(":%s", *port)
@@ -25,11 +34,25 @@ func main() {
 		log.Fatalf("failed to listen on %s: %v", addr, err)
 	}
 
+	pool := findpath.NewWorkerPool(findpath.PoolOptions{Workers: *workers, QueueDepth: *queueDepth})
+	defer pool.Close()
+
+	var cache *findpath.PathCache
+	if *cacheSize > 0 {
+		cache = findpath.NewPathCache(findpath.CacheOptions{MaxEntries: *cacheSize, TTL: *cacheTTL})
+	}
+
+	opts := app_grpc.ServerOptions{
+		Pool:  pool,
+		Cache: cache,
+		Maps:  findpath.NewMapRegistry(findpath.RegistryOptions{MaxMaps: *maxMaps, MaxCells: *maxMapCells, Cache: cache}),
+
+		StreamQueries: *streamQueries,
+	}
+
 	grpcServer := grpc.NewServer()
-	findpathv1.RegisterPathFinderServer(
-		grpcServer,
-		app_grpc.NewServer(),
-	)
+	findpathv1.RegisterPathFinderServer(grpcServer, app_grpc.NewServer(opts))
+	findpathv2.RegisterPathFinderServer(grpcServer, app_grpc.NewServerV2(opts))
 
 	go func() {
 		log.Printf("gRPC server listening on %s\n", addr)
@@ -45,5 +68,11 @@ func main() {
 
 	log.Println("Shutting down gRPC server gracefully...")
 	grpcServer.GracefulStop()
+
+	if cache != nil {
+		s := cache.Stats()
+		log.Printf("path cache: %d hits, %d misses, %d evictions, %d entries\n", s.Hits, s.Misses, s.Evictions, s.Entries)
+	}
+
 	log.Println("gRPC server stopped")
 }
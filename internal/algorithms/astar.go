@@ -1,213 +1,168 @@
 package algorithms
 
 import (
-	"container/heap"
+	"context"
 	"fmt"
 	"github.com/unomns/findpath/internal/model"
 	"slices"
 	"sort"
 	"sync"
+	"unsafe"
 )
 
-type AStarNode struct {
-	coords model.Node
-	gCost  int32 // Cost from the start node to this node
-	hCost  int32 // Heuristic cost from this node to the end node
-	fCost  int32 // Total cost (GCost + HCost)
-
-	parent *AStarNode
-	index  int
-}
-
-type PriorityQueue []*AStarNode
-
-func (pq PriorityQueue) Len() int { return len(pq) }
-
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].fCost < pq[j].fCost
-}
-
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
-}
-
-func (pq *PriorityQueue) Push(x any) {
-	n := len(*pq)
-	item := x.(*AStarNode)
-	item.index = n
-	*pq = append(*pq, item)
-}
-
-func (pq *PriorityQueue) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil  // don't stop the GC from reclaiming the item eventually
-	item.index = -1 // for safety
-	*pq = old[0 : n-1]
-	return item
-}
+// astarNodeSize approximates the memory of one generated node: its open list entry and
+// the per-cell cost, parent and generations.
+const astarNodeSize = unsafe.Sizeof(cellItem{}) + 4*unsafe.Sizeof(int32(0))
 
 type Astar struct {
 	debugMode bool
 	logs      map[string][]string
+	jumps     bool
 }
 
 func NewAstar(d bool) *Astar {
 	return &Astar{debugMode: d, logs: make(map[string][]string)}
 }
 
+// NewJumpPoint returns A* searching 26-connected voxel grids with jumps, see jumpSearch. It
+// pays off in volumes with large open spaces, which A* expands cell by cell. Among scattered
+// obstacles it expands about as many cells as A*, at a higher cost each. Other maps are
+// searched like A* does.
+func NewJumpPoint(d bool) *Astar {
+	a := NewAstar(d)
+	a.jumps = true
+
+	return a
+}
+
 func (a *Astar) Name() string {
+	if a.jumps {
+		return "Jump Point Search"
+	}
+
 	return "A* Search Algorithm"
 }
 
 var mutex sync.RWMutex
 
-func (a *Astar) Find(m model.GameMap, p *model.Player) []*model.Node {
-	if m.Grid[p.Start.Y][p.Start.X] > 0 {
+func (a *Astar) Find(ctx context.Context, m model.GameMap, p *model.Player, o Options) *Result {
+	bgt := newBudget(ctx, o, astarNodeSize)
+
+	if !walkable(&m, p.Start) {
 		a.debug(nil, "Wrong position! Only the '0' value is available to moving threw!")
 
-		return nil
+		return stopped(ReasonNoPath, bgt.stats(0))
 	}
 
-	curY := p.Start.Y
-	curX := p.Start.X
-
-	a.debug(nil, fmt.Sprintf("Player #%d finding path.. map lenght: %d, map width: %d\n", p.ID, m.Height, m.Width))
-	a.debug(nil, fmt.Sprintf("Start coords: %d %d", curY, curX))
-	a.debug(nil, fmt.Sprintf("Target coords: %d %d\n", p.Target.Y, p.Target.X))
-
-	skipped := make(map[string]*AStarNode)
-	pq := make(PriorityQueue, 0)
-	heap.Init(&pq)
-
-	target := &AStarNode{coords: model.Node{Y: p.Target.Y, X: p.Target.X}}
+	if !inBounds(&m, p.Target) {
+		a.debug(nil, "Wrong target! It is out of the map")
 
-	current := &AStarNode{coords: model.Node{Y: curY, X: curX}}
-	current.hCost = current.calculateHeuristic(target)
-	current.fCost = current.hCost + current.gCost
+		return stopped(ReasonNoPath, bgt.stats(0))
+	}
 
-	heap.Push(&pq, current)
+	if turnsEnabled(&m) {
+		path, cost, reason := headingSearch(&m, turnState{node: p.Start, heading: toDir(p.Heading)}, p.Target, bgt, nil, true)
+		if reason != ReasonFound {
+			return stopped(reason, bgt.stats(0))
+		}
 
-	finalNode := a.loop(m, target, &pq, skipped)
+		return found(path, bgt.stats(cost))
+	}
 
 	if a.debugMode {
-		a.printDebugLogs()
+		a.debug(nil, fmt.Sprintf("Player %s finding path.. map lenght: %d, map width: %d\n", p.ID, m.Height, m.Width))
+		a.debug(nil, fmt.Sprintf("Start coords: %v", p.Start))
+		a.debug(nil, fmt.Sprintf("Target coords: %v\n", p.Target))
 	}
 
-	if finalNode == nil {
-		return nil
+	var path []*model.Node
+	var cost int32
+	var reason StopReason
+	if a.jumps && jumpable(&m) {
+		path, cost, reason = a.jumpSearch(&m, p.Start, p.Target, bgt)
+	} else {
+		path, cost, reason = a.search(&m, p.Start, p.Target, bgt)
 	}
 
-	var path []*model.Node
-	for n := finalNode; n != nil; n = n.parent {
-		path = append(path, &n.coords)
+	if a.debugMode {
+		a.printDebugLogs()
 	}
 
-	slices.Reverse(path)
+	if reason != ReasonFound {
+		return partial(path, reason, bgt.stats(cost))
+	}
 
-	return path
+	return found(path, bgt.stats(cost))
 }
 
-func (a *Astar) loop(
-	m model.GameMap,
-	target *AStarNode,
-	pq *PriorityQueue,
-	skipped map[string]*AStarNode,
-) *AStarNode {
-	loopCounter := 0
+// search runs A* over flat cell indices. Its arrays come from a pool, so apart from the
+// returned path it doesn't allocate once the pool is warm. When it fails, the path is the
+// partial one if the options ask for it.
+func (a *Astar) search(m *model.GameMap, start model.Node, target model.Node, bgt *budget) ([]*model.Node, int32, StopReason) {
+	s := acquireCellState(int(m.Width * m.Height * m.Depth()))
+	defer releaseCellState(s)
 
-	for pq.Len() > 0 {
-		loopCounter++
-		current := heap.Pop(pq).(*AStarNode)
-		a.debug(current, fmt.Sprintf("[loop:%d] New Current coords | %v", loopCounter, current.coords))
+	goal := cellIndex(m, target)
+	first := cellIndex(m, start)
 
-		k := generateKey(current.coords.Y, current.coords.X)
-		skipped[k] = current
+	best, bestH := first, heuristic(m, start, target)
 
-		neighbours := current.neigbours(&m, skipped)
-		if len(neighbours) == 0 {
-			a.debug(current, "No neigbours found!")
+	s.reach(first, 0, -1)
+	s.open.push(cellItem{f: bgt.opts.estimate(m, start, target), cell: first})
+	bgt.generate(1)
 
-			continue
-		}
+	for len(s.open) > 0 {
+		bgt.open(len(s.open))
 
-		for _, n := range neighbours {
-			n.calculate(current)
-			heap.Push(pq, n)
-
-			if n.coords.Y == target.coords.Y && n.coords.X == target.coords.X {
-				a.debug(n, "\n###### Target detected successfully!!!\n")
-				return n
-			}
+		current := s.open.pop()
+		if s.closed[current.cell] == s.gen {
+			continue // stale entry, the cell was already reached cheaper
 		}
 
-		a.debug(current, fmt.Sprintf("[loop:%d] End of loop | continue", loopCounter))
-	}
-
-	return nil
-}
-
-func (n *AStarNode) neigbours(m *model.GameMap, skipped map[string]*AStarNode) []*AStarNode {
-	var res []*AStarNode
+		if reason, ok := bgt.expand(); !ok {
+			if a.debugMode {
+				a.debug(nil, fmt.Sprintf("[loop:%d] Search stopped: %s", bgt.expanded, reason))
+			}
 
-	curY := n.coords.Y
-	curX := n.coords.X
+			path, cost := s.partial(m, best, bgt.opts)
 
-	// left neighbor
-	if curX > 0 {
-		if neigbour := defineNode(curY, curX-1, &m.Grid, skipped); neigbour != nil {
-			res = append(res, neigbour)
+			return path, cost, reason
 		}
-	}
 
-	// right neighbor
-	if curX < (m.Width - 1) {
-		if neigbour := defineNode(curY, curX+1, &m.Grid, skipped); neigbour != nil {
-			res = append(res, neigbour)
+		node := cellNode(m, current.cell)
+		if bgt.opts.PartialPaths {
+			if h := heuristic(m, node, target); h < bestH {
+				best, bestH = current.cell, h
+			}
 		}
-	}
-
-	// top neighbor
-	if curY > 0 {
-		if neigbour := defineNode(curY-1, curX, &m.Grid, skipped); neigbour != nil {
-			res = append(res, neigbour)
+		if a.debugMode {
+			a.debug(&node, fmt.Sprintf("[loop:%d] New Current coords | %v", bgt.expanded, node))
 		}
-	}
 
-	// bottom neighbor
-	if curY < (m.Height - 1) {
-		if neigbour := defineNode(curY+1, curX, &m.Grid, skipped); neigbour != nil {
-			res = append(res, neigbour)
-		}
-	}
+		if current.cell == goal {
+			a.debug(&node, "\n###### Target detected successfully!!!\n")
 
-	return res
-}
+			return s.path(m, goal), current.g, ReasonFound
+		}
 
-func defineNode(y int32, x int32, grid *[][]int32, skipped map[string]*AStarNode) *AStarNode {
-	_, ok := skipped[generateKey(y, x)]
-	if ok || (*grid)[y][x] > 0 {
-		return nil
-	}
+		s.closed[current.cell] = s.gen
+		s.edges = appendEdges(s.edges[:0], m, node)
 
-	return &AStarNode{coords: model.Node{Y: y, X: x}}
-}
+		for _, e := range s.edges {
+			n, g := cellIndex(m, e.to), current.g+e.cost
+			if !s.improves(n, g) {
+				continue
+			}
 
-func generateKey(y int32, x int32) string {
-	return fmt.Sprintf("%d-%d", y, x)
-}
+			s.reach(n, g, current.cell)
+			s.open.push(cellItem{f: g + bgt.opts.estimate(m, e.to, target), g: g, cell: n})
+			bgt.generate(1)
+		}
+	}
 
-func (n *AStarNode) calculate(parent *AStarNode) {
-	n.hCost = parent.calculateHeuristic(n)
-	n.gCost = parent.gCost + 1
-	n.parent = parent
-}
+	path, cost := s.partial(m, best, bgt.opts)
 
-func (n *AStarNode) calculateHeuristic(to *AStarNode) int32 {
-	return abs(n.coords.Y-to.coords.Y) + abs(n.coords.X-to.coords.X) // Manhattan distance or Euclidean distance
+	return path, cost, ReasonNoPath
 }
 
 func abs(i int32) int32 {
@@ -219,18 +174,18 @@ func abs(i int32) int32 {
 }
 
 // DEBUGGING && LOGGING
-func (a *Astar) debug(n *AStarNode, msg string) {
+func (a *Astar) debug(n *model.Node, msg string) {
 	if !a.debugMode {
 		return
 	}
 
 	if n != nil {
-		msg = fmt.Sprintf("node %v | %s", n.coords, msg)
+		msg = fmt.Sprintf("node %v | %s", *n, msg)
 	}
 
 	var k string
 	if n != nil {
-		k = generateKey(n.coords.Y, n.coords.X)
+		k = fmt.Sprintf("%d-%d-%d", n.Z, n.Y, n.X)
 	} else {
 		k = "default"
 	}
@@ -0,0 +1,170 @@
+package algorithms
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// benchMap returns a prepared size×size map with the given share of blocked cells. The
+// corners and the cells around them are always walkable.
+func benchMap(b *testing.B, size int32, blocked float64) *model.GameMap {
+	r := rand.New(rand.NewSource(1))
+
+	m := &model.GameMap{Width: size, Height: size, Grid: make([][]int32, size)}
+	for y := range m.Grid {
+		m.Grid[y] = make([]int32, size)
+		for x := range m.Grid[y] {
+			if r.Float64() < blocked {
+				m.Grid[y][x] = 1
+			}
+		}
+	}
+
+	for i := range int32(3) {
+		for j := range int32(3) {
+			m.Grid[i][j], m.Grid[size-1-i][size-1-j] = 0, 0
+		}
+	}
+
+	if err := Prepare(m); err != nil {
+		b.Fatal(err)
+	}
+
+	return m
+}
+
+// A* finds paths as cheap as Dijkstra's on every kind of map.
+func TestAstarMatchesDijkstra(t *testing.T) {
+	cases := []struct {
+		name     string
+		features mapFeatures
+		compiled bool
+		voxel    model.Connectivity
+	}{
+		{name: "walls"},
+		{name: "portals", features: mapFeatures{portals: true}},
+		{name: "exits", features: mapFeatures{portals: true, exits: true}},
+		{name: "wrap", features: mapFeatures{wrap: true}},
+		{name: "levels", features: mapFeatures{levels: true, portals: true}},
+		{name: "cost layers", features: mapFeatures{costs: true, portals: true}},
+		{name: "turns", features: mapFeatures{turns: true, portals: true}},
+		{name: "compiled", features: mapFeatures{levels: true, portals: true, wrap: true, costs: true}, compiled: true},
+		{name: "voxel", features: mapFeatures{levels: true, costs: true}, voxel: model.Connectivity26},
+		{name: "compiled voxel", features: mapFeatures{levels: true, wrap: true}, compiled: true, voxel: model.Connectivity18},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(40))
+
+			for i := range 200 {
+				m := randomMap(t, r, c.features)
+				if c.voxel != model.ConnectivityLevels {
+					m.Connectivity = c.voxel
+					if err := Prepare(m); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if c.compiled {
+					PrepareMoves(m)
+				}
+
+				p := &model.Player{Start: randomWalkable(r, m), Target: randomWalkable(r, m)}
+
+				want := (&Dijkstra{}).Find(context.Background(), *m, p, Options{})
+				got := NewAstar(false).Find(context.Background(), *m, p, Options{})
+
+				if got.Reason != want.Reason || got.Stats.Cost != want.Stats.Cost {
+					t.Fatalf("map %d: a-star %s with cost %d, dijkstra %s with cost %d", i, got.Reason, got.Stats.Cost, want.Reason, want.Stats.Cost)
+				}
+
+				if got.Reason == ReasonFound {
+					if cost := followCost(t, m, p, got.Path); cost != got.Stats.Cost {
+						t.Fatalf("map %d: cost %d, walking the path costs %d: %v", i, got.Stats.Cost, cost, nodes(got.Path))
+					}
+				}
+			}
+		})
+	}
+}
+
+// Once the pool is warm, A* only allocates its result: the Result, the path and one array
+// for its nodes. A failed search only allocates the Result.
+func TestAstarAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool doesn't keep values with the race detector on")
+	}
+
+	m := &model.GameMap{Width: 64, Height: 64, Grid: make([][]int32, 64)}
+	for y := range m.Grid {
+		m.Grid[y] = make([]int32, 64)
+	}
+
+	for y := range int32(63) {
+		m.Grid[y][32] = 1 // a wall with a gap at the bottom
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAstar(false)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		player *model.Player
+		want   float64
+	}{
+		{"found", &model.Player{Target: model.Node{Y: 0, X: 63}}, 3},
+		{"no path", &model.Player{Target: model.Node{Y: 0, X: 32}}, 1},
+	}
+
+	for _, tt := range tests {
+		a.Find(ctx, *m, tt.player, Options{}) // warms up the pool
+
+		if got := testing.AllocsPerRun(100, func() { a.Find(ctx, *m, tt.player, Options{}) }); got > tt.want {
+			t.Errorf("%s: %v allocations per search, want at most %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func benchAstar(b *testing.B, m *model.GameMap) {
+	a := NewAstar(false)
+	p := &model.Player{Target: model.Node{Y: m.Height - 1, X: m.Width - 1}}
+	ctx := context.Background()
+
+	if res := a.Find(ctx, *m, p, Options{}); res.Reason != ReasonFound { // warms up the pool too
+		b.Fatalf("no path on the benchmark map: %s", res.Reason)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		a.Find(ctx, *m, p, Options{})
+	}
+}
+
+func BenchmarkAstarOpen256(b *testing.B) {
+	benchAstar(b, benchMap(b, 256, 0))
+}
+
+func BenchmarkAstarObstacles256(b *testing.B) {
+	benchAstar(b, benchMap(b, 256, 0.2))
+}
+
+func BenchmarkAstarObstacles1024(b *testing.B) {
+	benchAstar(b, benchMap(b, 1024, 0.2))
+}
+
+func BenchmarkAstarCompiled256(b *testing.B) {
+	m := benchMap(b, 256, 0.2)
+	PrepareMoves(m)
+
+	benchAstar(b, m)
+}
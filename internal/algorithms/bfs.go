@@ -1,16 +1,56 @@
 package algorithms
 
 import (
+	"context"
+	"unsafe"
+
 	"github.com/unomns/findpath/internal/model"
 )
 
+// Bfs finds the path with the fewest steps, it ignores cost layers and turn rules.
 type Bfs struct{}
 
 func (b *Bfs) Name() string {
 	return "Breadth-First Search"
 }
 
-func (b *Bfs) Find(m model.GameMap, p *model.Player) []*model.Node {
-	// TODO: second
-	return make([]*model.Node, 0)
+func (b *Bfs) Find(ctx context.Context, m model.GameMap, p *model.Player, o Options) *Result {
+	bgt := newBudget(ctx, o, 2*unsafe.Sizeof(model.Node{}))
+
+	if !walkable(&m, p.Start) {
+		return stopped(ReasonNoPath, bgt.stats(0))
+	}
+
+	parents := map[model.Node]model.Node{p.Start: p.Start}
+	queue := []model.Node{p.Start}
+	bgt.generate(1)
+
+	for len(queue) > 0 {
+		bgt.open(len(queue))
+
+		if reason, ok := bgt.expand(); !ok {
+			return stopped(reason, bgt.stats(0))
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == p.Target {
+			path := buildPath(parents, p.Start, p.Target)
+			return found(path, bgt.stats(pathCost(&m, toDir(p.Heading), path)))
+		}
+
+		for _, e := range edges(&m, current) {
+			n := e.to
+			if _, seen := parents[n]; seen {
+				continue
+			}
+
+			parents[n] = current
+			queue = append(queue, n)
+			bgt.generate(1)
+		}
+	}
+
+	return stopped(ReasonNoPath, bgt.stats(0))
 }
@@ -0,0 +1,65 @@
+package algorithms
+
+import (
+	"context"
+	"time"
+)
+
+// how many expansions happen between two context checks
+const ctxCheckInterval = 64
+
+// budget tracks the work done by one search, tells it when to stop and collects its stats.
+type budget struct {
+	ctx      context.Context
+	opts     Options
+	nodeSize int64
+	started  time.Time
+
+	expanded  int
+	generated int
+	peakOpen  int
+}
+
+func newBudget(ctx context.Context, o Options, nodeSize uintptr) *budget {
+	return &budget{ctx: ctx, opts: o, nodeSize: int64(nodeSize), started: time.Now()}
+}
+
+// expand registers one expansion. ok is false when the search has to stop.
+func (b *budget) expand() (reason StopReason, ok bool) {
+	if b.expanded%ctxCheckInterval == 0 && b.ctx.Err() != nil {
+		return ReasonCancelled, false
+	}
+
+	b.expanded++
+
+	if b.opts.MaxExpansions > 0 && b.expanded > b.opts.MaxExpansions {
+		return ReasonBudgetExceeded, false
+	}
+
+	if b.opts.MaxMemory > 0 && int64(b.generated)*b.nodeSize > b.opts.MaxMemory {
+		return ReasonBudgetExceeded, false
+	}
+
+	return ReasonFound, true
+}
+
+func (b *budget) generate(n int) {
+	b.generated += n
+}
+
+// open records the current size of the open list.
+func (b *budget) open(n int) {
+	if n > b.peakOpen {
+		b.peakOpen = n
+	}
+}
+
+func (b *budget) stats(cost int32) Stats {
+	return Stats{
+		Cost:      cost,
+		Expanded:  b.expanded,
+		Generated: b.generated,
+		PeakOpen:  b.peakOpen,
+		Duration:  time.Since(b.started),
+	}
+}
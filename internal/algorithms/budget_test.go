@@ -0,0 +1,111 @@
+package algorithms
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// kShortest runs Yen's search for three routes as a PathFinder.
+type kShortest struct{}
+
+func (kShortest) Name() string { return "yen" }
+
+func (kShortest) Find(ctx context.Context, m model.GameMap, p *model.Player, o Options) *Result {
+	return KShortestPaths(ctx, m, p, o, 3, 0)
+}
+
+// openMap returns a prepared map without walls.
+func openMap(t testing.TB, width, height int32) *model.GameMap {
+	t.Helper()
+
+	m := &model.GameMap{Width: width, Height: height, Grid: make([][]int32, height)}
+	for y := range m.Grid {
+		m.Grid[y] = make([]int32, width)
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestStopReasons(t *testing.T) {
+	plain := openMap(t, 20, 20)
+
+	turns := openMap(t, 20, 20)
+	turns.TurnCost = 2
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	p := &model.Player{Target: model.Node{Y: 19, X: 19}}
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		o    Options
+		want StopReason
+	}{
+		{"unlimited", context.Background(), Options{}, ReasonFound},
+		{"cancelled", cancelled, Options{}, ReasonCancelled},
+		{"deadline", expired, Options{}, ReasonCancelled},
+		{"max expansions", context.Background(), Options{MaxExpansions: 10}, ReasonBudgetExceeded},
+		{"max memory", context.Background(), Options{MaxMemory: 1}, ReasonBudgetExceeded},
+	}
+
+	searchers := finders()
+	searchers["yen"] = kShortest{}
+
+	for _, m := range []*model.GameMap{plain, turns} {
+		for name, f := range searchers {
+			for _, c := range cases {
+				res := f.Find(c.ctx, *m, p, c.o)
+				if res.Reason != c.want {
+					t.Errorf("%s, turn cost %d, %s: reason = %s, want %s", name, m.TurnCost, c.name, res.Reason, c.want)
+				}
+
+				if c.want != ReasonFound && res.Stats.Cost != 0 {
+					t.Errorf("%s, turn cost %d, %s: cost = %d without a path", name, m.TurnCost, c.name, res.Stats.Cost)
+				}
+
+				if c.o.MaxExpansions > 0 && res.Stats.Expanded > c.o.MaxExpansions+1 {
+					t.Errorf("%s, turn cost %d, %s: %d expansions", name, m.TurnCost, c.name, res.Stats.Expanded)
+				}
+			}
+		}
+	}
+}
+
+func TestStopReasonOnNavMesh(t *testing.T) {
+	mesh := &model.NavMesh{
+		Vertices: []model.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}},
+		Polygons: [][]int32{{0, 1, 2}, {0, 2, 3}},
+	}
+	if err := PrepareNavMesh(mesh); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &model.NavPlayer{Start: model.Point{X: 8, Y: 1}, Target: model.Point{X: 1, Y: 8}}
+
+	if res := FindOnNavMesh(context.Background(), mesh, p, Options{}); res.Reason != ReasonFound {
+		t.Errorf("reason = %s, want found", res.Reason)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if res := FindOnNavMesh(ctx, mesh, p, Options{}); res.Reason != ReasonCancelled {
+		t.Errorf("cancelled: reason = %s, want cancelled", res.Reason)
+	}
+
+	if res := FindOnNavMesh(context.Background(), mesh, p, Options{MaxExpansions: 1}); res.Reason != ReasonBudgetExceeded {
+		t.Errorf("max expansions: reason = %s, want budget_exceeded", res.Reason)
+	}
+}
@@ -0,0 +1,164 @@
+package algorithms
+
+import (
+	"sync"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// cellItem is an open list entry. Entries are never updated in place: a cheaper way to a
+// cell pushes a new entry, and the old one is skipped once the cell is closed.
+type cellItem struct {
+	f, g int32
+	cell int32
+}
+
+// cellHeap is a binary min-heap by f, preferring the entry further from the start on ties.
+type cellHeap []cellItem
+
+func (h cellHeap) less(i, j int) bool {
+	if h[i].f != h[j].f {
+		return h[i].f < h[j].f
+	}
+
+	return h[i].g > h[j].g
+}
+
+func (h *cellHeap) push(it cellItem) {
+	*h = append(*h, it)
+
+	q := *h
+	for i := len(q) - 1; i > 0; {
+		p := (i - 1) / 2
+		if !q.less(i, p) {
+			break
+		}
+
+		q[i], q[p] = q[p], q[i]
+		i = p
+	}
+}
+
+func (h *cellHeap) pop() cellItem {
+	q := *h
+	top := q[0]
+
+	last := len(q) - 1
+	q[0] = q[last]
+	q = q[:last]
+
+	for i := 0; ; {
+		c := 2*i + 1
+		if c >= len(q) {
+			break
+		}
+
+		if r := c + 1; r < len(q) && q.less(r, c) {
+			c = r
+		}
+
+		if !q.less(c, i) {
+			break
+		}
+
+		q[i], q[c] = q[c], q[i]
+		i = c
+	}
+
+	*h = q
+
+	return top
+}
+
+// cellState holds the per-cell data of one search in flat arrays indexed by cellIndex. States
+// are pooled, and an entry only counts when its generation is the current one, so a new
+// search doesn't have to clear anything.
+type cellState struct {
+	gen     uint32
+	reached []uint32 // generation in which the cell got its cost and parent
+	closed  []uint32 // generation in which the cell was expanded
+	cost    []int32
+	parent  []int32
+
+	open  cellHeap
+	edges []edge
+
+	// jump search only, see growJumps
+	entered  []uint32 // moves that entered the cell at its cost
+	expanded []uint32 // moves of entered the cell was expanded for
+	via      []uint8  // the move repeated from the parent to reach the cell
+}
+
+var cellStates = sync.Pool{New: func() any { return &cellState{} }}
+
+// acquireCellState returns a state ready for a new search on a map of size cells.
+func acquireCellState(size int) *cellState {
+	s := cellStates.Get().(*cellState)
+
+	if len(s.reached) < size {
+		s.reached = make([]uint32, size)
+		s.closed = make([]uint32, size)
+		s.cost = make([]int32, size)
+		s.parent = make([]int32, size)
+		s.gen = 0
+	}
+
+	s.gen++
+	if s.gen == 0 {
+		// wrapped around, entries of old searches could pass for current ones
+		clear(s.reached)
+		clear(s.closed)
+		s.gen = 1
+	}
+
+	s.open = s.open[:0]
+
+	return s
+}
+
+func releaseCellState(s *cellState) {
+	cellStates.Put(s)
+}
+
+// reach records a way to the cell, parent is -1 for the start.
+func (s *cellState) reach(cell int32, cost int32, parent int32) {
+	s.reached[cell] = s.gen
+	s.cost[cell] = cost
+	s.parent[cell] = parent
+}
+
+// improves reports whether cost is a cheaper way to a cell that isn't closed yet.
+func (s *cellState) improves(cell int32, cost int32) bool {
+	if s.closed[cell] == s.gen {
+		return false
+	}
+
+	return s.reached[cell] != s.gen || cost < s.cost[cell]
+}
+
+// path follows the parents from the goal back to the start. All nodes share one allocation.
+func (s *cellState) path(m *model.GameMap, goal int32) []*model.Node {
+	n := 0
+	for c := goal; c >= 0; c = s.parent[c] {
+		n++
+	}
+
+	nodes := make([]model.Node, n)
+	path := make([]*model.Node, n)
+
+	for c, i := goal, n-1; c >= 0; c, i = s.parent[c], i-1 {
+		nodes[i] = cellNode(m, c)
+		path[i] = &nodes[i]
+	}
+
+	return path
+}
+
+// partial returns the path to best and its cost when the options ask for partial paths.
+func (s *cellState) partial(m *model.GameMap, best int32, o Options) ([]*model.Node, int32) {
+	if !o.PartialPaths {
+		return nil, 0
+	}
+
+	return s.path(m, best), s.cost[best]
+}
@@ -0,0 +1,169 @@
+package algorithms
+
+import (
+	"math/bits"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// gridOffsets lists the dz, dy, dx of the moves on non-voxel maps in the order edges returns them.
+var gridOffsets = [4][3]int32{{0, 0, -1}, {0, 0, 1}, {0, -1, 0}, {0, 1, 0}}
+
+// PrepareMoves stores the steps every cell allows, so that searches don't have to check
+// the bounds, walkability, exits and corners of each neighbour again. It must be called
+// after Prepare, and again whenever the map changes.
+func PrepareMoves(m *model.GameMap) {
+	m.Moves = make([]uint32, m.Width*m.Height*m.Depth())
+
+	forEachCell(m, func(n model.Node) {
+		m.Moves[cellIndex(m, n)] = cellMoves(m, n)
+	})
+}
+
+// UpdateMoves recomputes the moves of the changed cells and their neighbours, the only ones
+// a change of walkability can affect. m.Moves must not be shared with another map.
+func UpdateMoves(m *model.GameMap, changed []model.Node) {
+	for _, n := range changed {
+		for dz := int32(-1); dz <= 1; dz++ {
+			for dy := int32(-1); dy <= 1; dy++ {
+				for dx := int32(-1); dx <= 1; dx++ {
+					c, ok := voxelOffset(m, n, dz, dy, dx)
+					if dz == 0 && dy == 0 && dx == 0 {
+						c, ok = n, true
+					}
+
+					if ok {
+						m.Moves[cellIndex(m, c)] = cellMoves(m, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func cellMoves(m *model.GameMap, n model.Node) uint32 {
+	if !walkable(m, n) {
+		return 0
+	}
+
+	var moves uint32
+	for _, e := range appendCheckedEdges(nil, m, n) {
+		if !e.portal {
+			moves |= 1 << offsetBit(n, e.to)
+		}
+	}
+
+	return moves
+}
+
+// PrepareClearance measures, for every cell, the largest walkable square it is the top-left
+// corner of. Wrapped edges are not taken into account.
+func PrepareClearance(m *model.GameMap) {
+	m.Clearance = make([]int32, m.Width*m.Height*m.Depth())
+
+	for z := range m.Depth() {
+		clearanceRegion(m, z, m.Height-1, m.Width-1)
+	}
+}
+
+// clearanceRegion computes the clearance of the cells of level z from [0 0] to [toY toX].
+func clearanceRegion(m *model.GameMap, z int32, toY int32, toX int32) {
+	at := func(y, x int32) int32 {
+		if y >= m.Height || x >= m.Width {
+			return 0
+		}
+
+		return m.Clearance[cellIndex(m, model.Node{Y: y, X: x, Z: z})]
+	}
+
+	for y := toY; y >= 0; y-- {
+		for x := toX; x >= 0; x-- {
+			n := model.Node{Y: y, X: x, Z: z}
+
+			var c int32
+			if walkable(m, n) {
+				c = 1 + min(at(y+1, x), at(y, x+1), at(y+1, x+1))
+			}
+
+			m.Clearance[cellIndex(m, n)] = c
+		}
+	}
+}
+
+// UpdateClearance recomputes the clearance after the cells changed. Only cells above and left
+// of a change can be affected. m.Clearance must not be shared with another map.
+func UpdateClearance(m *model.GameMap, changed []model.Node) {
+	bottomRight := make(map[int32]model.Node) // per level
+	for _, n := range changed {
+		br, ok := bottomRight[n.Z]
+		if !ok {
+			br = n
+		}
+
+		bottomRight[n.Z] = model.Node{Z: n.Z, Y: max(br.Y, n.Y), X: max(br.X, n.X)}
+	}
+
+	for z, br := range bottomRight {
+		clearanceRegion(m, z, br.Y, br.X)
+	}
+}
+
+// ClearanceAt returns the clearance of a cell, 0 when it is outside the map or the map has
+// no clearance.
+func ClearanceAt(m *model.GameMap, n model.Node) int32 {
+	if m.Clearance == nil || !inBounds(m, n) {
+		return 0
+	}
+
+	return m.Clearance[cellIndex(m, n)]
+}
+
+// movesEdges is appendEdges for a map with precomputed moves.
+func movesEdges(res []edge, m *model.GameMap, n model.Node) []edge {
+	mask := m.Moves[cellIndex(m, n)]
+
+	step := func(dz, dy, dx int32) {
+		to, _ := voxelOffset(m, n, dz, dy, dx)
+		res = append(res, edge{to: to, cost: enterCost(m, to)})
+	}
+
+	if isVoxel(m) {
+		for mask != 0 {
+			b := int32(bits.TrailingZeros32(mask))
+			mask &= mask - 1
+
+			step(b/9-1, b/3%3-1, b%3-1)
+		}
+	} else {
+		for _, o := range gridOffsets {
+			if mask&(1<<bitOf(o[0], o[1], o[2])) != 0 {
+				step(o[0], o[1], o[2])
+			}
+		}
+	}
+
+	for _, p := range m.PortalsFrom[n] {
+		res = append(res, edge{to: p.To, cost: p.Cost, portal: true})
+	}
+
+	return res
+}
+
+// offsetBit returns the bit of the step between two neighbouring cells. A step longer than
+// one cell crosses a wrapped edge and goes the other way.
+func offsetBit(from model.Node, to model.Node) int32 {
+	dy, dx := to.Y-from.Y, to.X-from.X
+	if abs(dy) > 1 {
+		dy = -dy / abs(dy)
+	}
+
+	if abs(dx) > 1 {
+		dx = -dx / abs(dx)
+	}
+
+	return bitOf(to.Z-from.Z, dy, dx)
+}
+
+func bitOf(dz, dy, dx int32) int32 {
+	return (dz+1)*9 + (dy+1)*3 + dx + 1
+}
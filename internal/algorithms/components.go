@@ -0,0 +1,203 @@
+package algorithms
+
+import (
+	"github.com/unomns/findpath/internal/model"
+)
+
+// LabelComponents splits the walkable cells into regions connected by the movement rules of
+// the map: connectivity, wrap, connectors, portals and exits. One-way moves join regions as
+// if they went both ways, so cells with the same label may still be unreachable from each
+// other, but cells with different labels never are. Prepare must be called first.
+func LabelComponents(m *model.GameMap) {
+	size := m.Width * m.Height * m.Depth()
+	parent := make([]int32, size)
+	for i := range parent {
+		parent[i] = int32(i)
+	}
+
+	find := func(i int32) int32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	var buf []edge
+
+	forEachCell(m, func(n model.Node) {
+		if !walkable(m, n) {
+			return
+		}
+
+		buf = appendEdges(buf[:0], m, n)
+		for _, e := range buf {
+			if !walkable(m, e.to) {
+				continue
+			}
+
+			if a, b := find(cellIndex(m, n)), find(cellIndex(m, e.to)); a != b {
+				parent[a] = b
+			}
+		}
+	})
+
+	labels := make([]int32, size)
+	byRoot := make(map[int32]int32)
+	m.ComponentSizes = nil
+
+	forEachCell(m, func(n model.Node) {
+		i := cellIndex(m, n)
+		if !walkable(m, n) {
+			labels[i] = -1
+			return
+		}
+
+		root := find(i)
+		label, ok := byRoot[root]
+		if !ok {
+			label = int32(len(m.ComponentSizes))
+			byRoot[root] = label
+			m.ComponentSizes = append(m.ComponentSizes, 0)
+		}
+
+		labels[i] = label
+		m.ComponentSizes[label]++
+	})
+
+	m.Components = labels
+}
+
+// UpdateComponents relabels a labelled map after the cells in changed became walkable or
+// blocked. Only the regions with a cell next to a changed one are labelled again: moves
+// only change around those cells, and portals never start or end on them. The old labels
+// are left as they are, m gets new ones. Labels of the other regions may be renumbered.
+func UpdateComponents(m *model.GameMap, changed []model.Node) {
+	old, oldSizes := m.Components, m.ComponentSizes
+
+	touched := make([]bool, len(oldSizes))
+	for _, n := range changed {
+		for dz := int32(-1); dz <= 1; dz++ {
+			for dy := int32(-1); dy <= 1; dy++ {
+				for dx := int32(-1); dx <= 1; dx++ {
+					c, ok := voxelOffset(m, n, dz, dy, dx)
+					if dz == 0 && dy == 0 && dx == 0 {
+						c, ok = n, true
+					}
+
+					if ok {
+						if label := old[cellIndex(m, c)]; label >= 0 {
+							touched[label] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// the untouched regions keep their order, the touched ones are labelled after them
+	remap := make([]int32, len(oldSizes))
+	m.ComponentSizes = nil
+
+	for label, size := range oldSizes {
+		remap[label] = -1
+		if !touched[label] {
+			remap[label] = int32(len(m.ComponentSizes))
+			m.ComponentSizes = append(m.ComponentSizes, size)
+		}
+	}
+
+	// the cells of touched regions and the changed cells that are walkable now, by position
+	// in the list
+	var cells []model.Node
+	pos := make(map[int32]int32)
+
+	labels := make([]int32, len(old))
+
+	forEachCell(m, func(n model.Node) {
+		i := cellIndex(m, n)
+
+		switch label := old[i]; {
+		case !walkable(m, n):
+			labels[i] = -1
+		case label >= 0 && remap[label] >= 0:
+			labels[i] = remap[label]
+		default:
+			pos[i] = int32(len(cells))
+			cells = append(cells, n)
+		}
+	})
+
+	parent := make([]int32, len(cells))
+	for i := range parent {
+		parent[i] = int32(i)
+	}
+
+	find := func(i int32) int32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	var buf []edge
+
+	for k, n := range cells {
+		buf = appendEdges(buf[:0], m, n)
+		for _, e := range buf {
+			// edges of touched regions only lead to touched regions and changed cells
+			if to, ok := pos[cellIndex(m, e.to)]; ok {
+				if a, b := find(int32(k)), find(to); a != b {
+					parent[a] = b
+				}
+			}
+		}
+	}
+
+	byRoot := make(map[int32]int32)
+	for k, n := range cells {
+		root := find(int32(k))
+		label, ok := byRoot[root]
+		if !ok {
+			label = int32(len(m.ComponentSizes))
+			byRoot[root] = label
+			m.ComponentSizes = append(m.ComponentSizes, 0)
+		}
+
+		labels[cellIndex(m, n)] = label
+		m.ComponentSizes[label]++
+	}
+
+	m.Components = labels
+}
+
+// Component returns the region label of a cell, -1 when it is blocked, outside the map or
+// the map is not labelled.
+func Component(m *model.GameMap, n model.Node) int32 {
+	if m.Components == nil || !inBounds(m, n) {
+		return -1
+	}
+
+	return m.Components[cellIndex(m, n)]
+}
+
+// DifferentRegions reports whether the start and the target are walkable cells of different
+// regions, so that no path can connect them.
+func DifferentRegions(m *model.GameMap, start model.Node, target model.Node) bool {
+	a, b := Component(m, start), Component(m, target)
+
+	return a >= 0 && b >= 0 && a != b
+}
+
+func forEachCell(m *model.GameMap, f func(n model.Node)) {
+	for z := range m.Depth() {
+		for y := range m.Height {
+			for x := range m.Width {
+				f(model.Node{Y: y, X: x, Z: z})
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Relabelling the regions around changed cells splits the map like labelling it again.
+func TestUpdateComponentsMatchesLabelling(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+
+	maps := func(i int) *model.GameMap {
+		switch i % 4 {
+		case 0:
+			return randomMap(t, r, mapFeatures{levels: true, portals: true, exits: true})
+		case 1:
+			return randomMap(t, r, mapFeatures{wrap: true, exits: true})
+		default:
+			c := []model.Connectivity{model.Connectivity6, model.Connectivity18, model.Connectivity26}[r.Intn(3)]
+			return randomVolume(t, r, 3+r.Int31n(3), 0.3, c)
+		}
+	}
+
+	for i := range 400 {
+		m := maps(i)
+		if i%8 < 4 {
+			PrepareMoves(m)
+		}
+
+		LabelComponents(m)
+		old := slices.Clone(m.Components)
+
+		// portals must start and end on walkable cells, leave their cells alone
+		ends := make(map[model.Node]bool)
+		for from, ps := range m.PortalsFrom {
+			ends[from] = true
+			for _, p := range ps {
+				ends[p.To] = true
+			}
+		}
+
+		var changed []model.Node
+		for range 1 + r.Intn(4) {
+			n := model.Node{Z: r.Int31n(m.Depth()), Y: r.Int31n(m.Height), X: r.Int31n(m.Width)}
+			if ends[n] || slices.Contains(changed, n) {
+				continue
+			}
+
+			m.Levels[n.Z][n.Y][n.X] = 1 - m.Levels[n.Z][n.Y][n.X]
+			changed = append(changed, n)
+		}
+
+		if m.Moves != nil {
+			UpdateMoves(m, changed)
+		}
+
+		labelled := m.Components
+		UpdateComponents(m, changed)
+		got, gotSizes := m.Components, m.ComponentSizes
+
+		if !slices.Equal(labelled, old) {
+			t.Fatalf("map %d: the old labels changed", i)
+		}
+
+		LabelComponents(m)
+		want, wantSizes := m.Components, m.ComponentSizes
+
+		if len(gotSizes) != len(wantSizes) {
+			t.Fatalf("map %d: %d regions, want %d", i, len(gotSizes), len(wantSizes))
+		}
+
+		// the same regions, maybe under other labels
+		toWant := make(map[int32]int32)
+		for k := range got {
+			if (got[k] < 0) != (want[k] < 0) {
+				t.Fatalf("map %d, cell %d: label %d, want %d", i, k, got[k], want[k])
+			}
+
+			if got[k] < 0 {
+				continue
+			}
+
+			if l, ok := toWant[got[k]]; ok && l != want[k] {
+				t.Fatalf("map %d, cell %d: region %d is split in %d and %d", i, k, got[k], l, want[k])
+			}
+
+			toWant[got[k]] = want[k]
+		}
+
+		for g, w := range toWant {
+			if gotSizes[g] != wantSizes[w] {
+				t.Fatalf("map %d: region %d has size %d, want %d", i, g, gotSizes[g], wantSizes[w])
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// prepareCosts blends the map cost layers into m.ExtraCost.
+func prepareCosts(m *model.GameMap) error {
+	if len(m.CostLayers) == 0 {
+		m.ExtraCost = nil
+		return nil
+	}
+
+	size := int(m.Depth() * m.Width * m.Height)
+	sum := make([]float64, size)
+
+	for i, l := range m.CostLayers {
+		w, ok := m.LayerWeights[l.Name]
+		if !ok {
+			w = 1
+		}
+
+		if w == 0 {
+			continue
+		}
+
+		if l.Dense != nil {
+			if len(l.Dense) != size {
+				return mapError(fmt.Sprintf("cost_layers[%d].dense", i), "size %d does not match depth × width × height", len(l.Dense))
+			}
+
+			for i, c := range l.Dense {
+				sum[i] += w * float64(c)
+			}
+		}
+
+		for k, c := range l.Cells {
+			n := model.Node{Y: c.Y, X: c.X, Z: c.Z}
+			if !inBounds(m, n) {
+				return mapError(fmt.Sprintf("cost_layers[%d].cells[%d]", i, k), "cell %v is out of the map", n)
+			}
+
+			sum[cellIndex(m, n)] += w * float64(c.Cost)
+		}
+
+		for k, s := range l.Sources {
+			if err := addInfluence(m, sum, s, w); err != nil {
+				return &MapError{Field: fmt.Sprintf("cost_layers[%d].sources[%d]", i, k), Err: err}
+			}
+		}
+	}
+
+	m.ExtraCost = make([]int32, size)
+	for i, c := range sum {
+		if c > 0 {
+			m.ExtraCost[i] = int32(math.Round(c))
+		}
+	}
+
+	return nil
+}
+
+func addInfluence(m *model.GameMap, sum []float64, s model.InfluenceSource, w float64) error {
+	if s.Radius < 0 {
+		return fmt.Errorf("source [%d %d] has negative radius", s.Y, s.X)
+	}
+
+	if s.Z < 0 || s.Z >= m.Depth() {
+		return fmt.Errorf("source [%d %d] is on unknown level %d", s.Y, s.X, s.Z)
+	}
+
+	var fade func(d float64) float64
+	switch s.Falloff {
+	case model.FalloffConstant:
+		fade = func(d float64) float64 { return 1 }
+	case model.FalloffLinear, "":
+		fade = func(d float64) float64 { return 1 - d/float64(s.Radius+1) }
+	case model.FalloffQuadratic:
+		fade = func(d float64) float64 { f := 1 - d/float64(s.Radius+1); return f * f }
+	default:
+		return fmt.Errorf("source [%d %d] has unknown falloff %q", s.Y, s.X, s.Falloff)
+	}
+
+	for y := max(0, s.Y-s.Radius); y <= min(m.Height-1, s.Y+s.Radius); y++ {
+		for x := max(0, s.X-s.Radius); x <= min(m.Width-1, s.X+s.Radius); x++ {
+			d := math.Hypot(float64(y-s.Y), float64(x-s.X))
+			if d > float64(s.Radius) {
+				continue
+			}
+
+			sum[cellIndex(m, model.Node{Y: y, X: x, Z: s.Z})] += w * float64(s.Strength) * fade(d)
+		}
+	}
+
+	return nil
+}
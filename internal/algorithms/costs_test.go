@@ -0,0 +1,93 @@
+package algorithms
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+func TestCostLayersBlend(t *testing.T) {
+	m := openMap(t, 3, 1)
+	m.CostLayers = []model.CostLayer{
+		{Name: "mud", Cells: []model.CellCost{{X: 1, Cost: 4}, {X: 2, Cost: 3}}},
+		{Name: "fire", Dense: []int32{1, 2, 0}},
+		{Name: "ice", Dense: []int32{5, 5, 5}},
+		{Name: "road", Cells: []model.CellCost{{X: 2, Cost: -6}}},
+	}
+	m.LayerWeights = map[string]float64{"mud": 0.5, "ice": 0}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	// fire and road weigh 1, ice 0; a negative sum doesn't make a cell cheaper than 1
+	if want := []int32{1, 4, 0}; !slices.Equal(m.ExtraCost, want) {
+		t.Errorf("extra cost = %v, want %v", m.ExtraCost, want)
+	}
+}
+
+func TestInfluenceFalloff(t *testing.T) {
+	cases := []struct {
+		falloff model.Falloff
+		want    [3]int32 // extra cost 0, 1 and 2 cells away from the source
+	}{
+		{model.FalloffConstant, [3]int32{9, 9, 9}},
+		{model.FalloffLinear, [3]int32{9, 6, 3}},
+		{"", [3]int32{9, 6, 3}},
+		{model.FalloffQuadratic, [3]int32{9, 4, 1}},
+	}
+
+	for _, c := range cases {
+		m := openMap(t, 5, 5)
+		m.CostLayers = []model.CostLayer{{
+			Name:    "tower",
+			Sources: []model.InfluenceSource{{Y: 2, X: 2, Strength: 9, Radius: 2, Falloff: c.falloff}},
+		}}
+
+		if err := Prepare(m); err != nil {
+			t.Fatal(err)
+		}
+
+		at := func(y, x int32) int32 {
+			return m.ExtraCost[y*m.Width+x]
+		}
+
+		if got := [3]int32{at(2, 2), at(2, 3), at(0, 2)}; got != c.want {
+			t.Errorf("%q: extra cost by distance = %v, want %v", c.falloff, got, c.want)
+		}
+
+		// the corners are 2.8 cells away, out of the radius
+		if got := at(0, 0); got != 0 {
+			t.Errorf("%q: extra cost in the corner = %d, want 0", c.falloff, got)
+		}
+	}
+}
+
+func TestSearchAvoidsCostlyCells(t *testing.T) {
+	m := openMap(t, 3, 3)
+	m.CostLayers = []model.CostLayer{{
+		Name:    "tower",
+		Sources: []model.InfluenceSource{{Y: 1, X: 1, Strength: 5, Falloff: model.FalloffConstant}},
+	}}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &model.Player{Start: model.Node{Y: 1}, Target: model.Node{Y: 1, X: 2}}
+
+	for name, f := range map[string]PathFinder{"a-star": NewAstar(false), "dijkstra": &Dijkstra{}} {
+		res := f.Find(context.Background(), *m, p, Options{})
+		if res.Reason != ReasonFound {
+			t.Fatalf("%s: reason = %s, want found", name, res.Reason)
+		}
+
+		// through the centre costs 1 + 6, around it 4
+		centre := slices.ContainsFunc(res.Path, func(n *model.Node) bool { return *n == model.Node{Y: 1, X: 1} })
+		if res.Stats.Cost != 4 || centre {
+			t.Errorf("%s: cost %d, through the centre %v, want 4 around it", name, res.Stats.Cost, centre)
+		}
+	}
+}
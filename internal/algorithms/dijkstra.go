@@ -1,16 +1,111 @@
 package algorithms
 
 import (
+	"container/heap"
+	"context"
+	"unsafe"
+
 	"github.com/unomns/findpath/internal/model"
 )
 
+type dijkstraItem struct {
+	node model.Node
+	cost int32
+}
+
+type dijkstraQueue []dijkstraItem
+
+func (q dijkstraQueue) Len() int           { return len(q) }
+func (q dijkstraQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q dijkstraQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *dijkstraQueue) Push(x any) { *q = append(*q, x.(dijkstraItem)) }
+
+func (q *dijkstraQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
+
 type Dijkstra struct{}
 
 func (d *Dijkstra) Name() string {
 	return "Dijkstra's Algorithm"
 }
 
-func (d *Dijkstra) Find(m model.GameMap, p *model.Player) []*model.Node {
-	// TODO: third
-	return make([]*model.Node, 0)
+func (d *Dijkstra) Find(ctx context.Context, m model.GameMap, p *model.Player, o Options) *Result {
+	bgt := newBudget(ctx, o, dijkstraNodeSize)
+
+	if !walkable(&m, p.Start) {
+		return stopped(ReasonNoPath, bgt.stats(0))
+	}
+
+	path, cost, reason := dijkstraSearch(&m, turnState{node: p.Start, heading: toDir(p.Heading)}, p.Target, bgt, nil)
+	if reason != ReasonFound {
+		return stopped(reason, bgt.stats(0))
+	}
+
+	return found(path, bgt.stats(cost))
+}
+
+const dijkstraNodeSize = unsafe.Sizeof(dijkstraItem{}) + 2*unsafe.Sizeof(model.Node{})
+
+// dijkstraSearch finds the cheapest path from the start cell to target. allow reports whether
+// the step between two adjacent cells may be taken, nil allows every step.
+// The heading and turns of the start only matter on maps with turn rules.
+func dijkstraSearch(
+	m *model.GameMap,
+	from turnState,
+	target model.Node,
+	bgt *budget,
+	allow func(from, to model.Node) bool,
+) ([]*model.Node, int32, StopReason) {
+	if turnsEnabled(m) {
+		return headingSearch(m, from, target, bgt, allow, false)
+	}
+
+	start := from.node
+
+	costs := map[model.Node]int32{start: 0}
+	parents := map[model.Node]model.Node{start: start}
+	pq := &dijkstraQueue{{node: start}}
+	bgt.generate(1)
+
+	for pq.Len() > 0 {
+		bgt.open(pq.Len())
+
+		current := heap.Pop(pq).(dijkstraItem)
+		if current.cost > costs[current.node] {
+			continue // stale entry, a cheaper one was already expanded
+		}
+
+		if reason, ok := bgt.expand(); !ok {
+			return nil, 0, reason
+		}
+
+		if current.node == target {
+			return buildPath(parents, start, target), current.cost, ReasonFound
+		}
+
+		for _, e := range edges(m, current.node) {
+			n := e.to
+			if allow != nil && !allow(current.node, n) {
+				continue
+			}
+
+			cost := current.cost + e.cost
+			if known, ok := costs[n]; ok && known <= cost {
+				continue
+			}
+
+			costs[n] = cost
+			parents[n] = current.node
+			heap.Push(pq, dijkstraItem{node: n, cost: cost})
+			bgt.generate(1)
+		}
+	}
+
+	return nil, 0, ReasonNoPath
 }
@@ -0,0 +1,58 @@
+package algorithms
+
+import "github.com/unomns/findpath/internal/model"
+
+// funnel is the simple stupid funnel algorithm: it keeps a funnel from the last path corner
+// (the apex) through the crossed edges and adds a corner whenever one side of the funnel
+// would cross the other.
+func funnel(mesh *model.NavMesh, start model.Point, goal model.Point, links []model.NavLink) []model.Point {
+	type portal struct{ left, right model.Point }
+
+	ports := make([]portal, 0, len(links)+2)
+	ports = append(ports, portal{start, start})
+	for _, l := range links {
+		ports = append(ports, portal{mesh.Vertices[l.Left], mesh.Vertices[l.Right]})
+	}
+	ports = append(ports, portal{goal, goal})
+
+	path := []model.Point{start}
+	apex, left, right := start, start, start
+	apexIdx, leftIdx, rightIdx := 0, 0, 0
+
+	for i := 1; i < len(ports); i++ {
+		l, r := ports[i].left, ports[i].right
+
+		// tighten the right side
+		if cross(apex, right, r) >= 0 {
+			if apex == right || cross(apex, left, r) < 0 {
+				right, rightIdx = r, i
+			} else {
+				// the right side crosses the left one, the left point becomes a corner
+				path = append(path, left)
+				apex, apexIdx = left, leftIdx
+				right, rightIdx = apex, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+
+		// tighten the left side
+		if cross(apex, left, l) <= 0 {
+			if apex == left || cross(apex, right, l) > 0 {
+				left, leftIdx = l, i
+			} else {
+				path = append(path, right)
+				apex, apexIdx = right, rightIdx
+				left, leftIdx = apex, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+	}
+
+	if path[len(path)-1] != goal {
+		path = append(path, goal)
+	}
+
+	return path
+}
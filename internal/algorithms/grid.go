@@ -0,0 +1,229 @@
+package algorithms
+
+import (
+	"slices"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// InBounds reports whether the cell is on the map.
+func InBounds(m *model.GameMap, n model.Node) bool {
+	return inBounds(m, n)
+}
+
+// Walkable reports whether a player can stand on the cell, which has to be on the map.
+func Walkable(m *model.GameMap, n model.Node) bool {
+	return walkable(m, n)
+}
+
+func inBounds(m *model.GameMap, n model.Node) bool {
+	return n.Z >= 0 && n.Z < m.Depth() && n.Y >= 0 && n.Y < m.Height && n.X >= 0 && n.X < m.Width
+}
+
+// walkable reports whether a player can stand on the cell.
+func walkable(m *model.GameMap, n model.Node) bool {
+	return m.Levels.At(n) == 0
+}
+
+// cellIndex returns the position of the cell in the flat per-cell arrays of the map.
+func cellIndex(m *model.GameMap, n model.Node) int32 {
+	return (n.Z*m.Height+n.Y)*m.Width + n.X
+}
+
+// cellNode is the inverse of cellIndex.
+func cellNode(m *model.GameMap, i int32) model.Node {
+	level := m.Width * m.Height
+
+	return model.Node{Z: i / level, Y: i % level / m.Width, X: i % m.Width}
+}
+
+// edge is a move available from a cell.
+type edge struct {
+	to     model.Node
+	cost   int32
+	portal bool
+}
+
+// edges returns the moves available from n: the walkable adjacent cells its exit mask lets
+// through in left, right, top, bottom order, then the portals starting at n. Voxel maps
+// are handled by voxelEdges, compiled maps by movesEdges.
+func edges(m *model.GameMap, n model.Node) []edge {
+	return appendEdges(nil, m, n)
+}
+
+// appendEdges is edges appending to res, so that searches can reuse one buffer.
+func appendEdges(res []edge, m *model.GameMap, n model.Node) []edge {
+	if m.Moves != nil {
+		return movesEdges(res, m, n)
+	}
+
+	return appendCheckedEdges(res, m, n)
+}
+
+// appendCheckedEdges is appendEdges checking every neighbour instead of using the moves.
+func appendCheckedEdges(res []edge, m *model.GameMap, n model.Node) []edge {
+	if isVoxel(m) {
+		return voxelEdges(res, m, n)
+	}
+
+	step := func(d dir, y int32, x int32) {
+		to := model.Node{Y: y, X: x, Z: n.Z}
+		if to == n || !canExit(m, n, d) || !walkable(m, to) {
+			return
+		}
+
+		res = append(res, edge{to: to, cost: enterCost(m, to)})
+	}
+
+	if n.X > 0 {
+		step(dirLeft, n.Y, n.X-1)
+	} else if wrapsX(m) {
+		step(dirLeft, n.Y, m.Width-1)
+	}
+
+	if n.X < (m.Width - 1) {
+		step(dirRight, n.Y, n.X+1)
+	} else if wrapsX(m) {
+		step(dirRight, n.Y, 0)
+	}
+
+	if n.Y > 0 {
+		step(dirUp, n.Y-1, n.X)
+	} else if wrapsY(m) {
+		step(dirUp, m.Height-1, n.X)
+	}
+
+	if n.Y < (m.Height - 1) {
+		step(dirDown, n.Y+1, n.X)
+	} else if wrapsY(m) {
+		step(dirDown, 0, n.X)
+	}
+
+	for _, p := range m.PortalsFrom[n] {
+		res = append(res, edge{to: p.To, cost: p.Cost, portal: true})
+	}
+
+	return res
+}
+
+func wrapsX(m *model.GameMap) bool {
+	return m.Wrap == model.WrapHorizontal || m.Wrap == model.WrapBoth
+}
+
+func wrapsY(m *model.GameMap) bool {
+	return m.Wrap == model.WrapVertical || m.Wrap == model.WrapBoth
+}
+
+func canExit(m *model.GameMap, n model.Node, d dir) bool {
+	return m.ExitMask == nil || m.ExitMask[cellIndex(m, n)]&d.bit() != 0
+}
+
+// enterCost returns the cost of stepping onto an adjacent cell: the base terrain cost plus
+// the cost layers of the cell. It is never below 1.
+func enterCost(m *model.GameMap, to model.Node) int32 {
+	if m.ExtraCost == nil {
+		return 1
+	}
+
+	return 1 + m.ExtraCost[cellIndex(m, to)]
+}
+
+// pathCost returns the cost of the cheapest moves the searches could have taken along the
+// path, turns included, starting with the given heading. Exit masks apply and portals keep
+// the heading. The turn limit is not checked.
+func pathCost(m *model.GameMap, heading dir, path []*model.Node) int32 {
+	cost, _, _ := walkPath(m, turnState{node: *path[0], heading: heading}, path, 0)
+
+	return cost
+}
+
+// heuristic estimates the cost from n to target without overestimating it. Without portals
+// it is the Manhattan distance (across the wrapped edges too); with them a path may also jump
+// through the nearest portal. Connectors count as portals.
+func heuristic(m *model.GameMap, n model.Node, target model.Node) int32 {
+	h := distance(m, n, target)
+	if m.PortalDistance == nil {
+		return h
+	}
+
+	toPortal := m.PortalDistance[distanceIndex(m, n)]
+	fromPortal := m.ExitDistance[distanceIndex(m, target)]
+
+	return min(h, toPortal+min(h, m.MinPortalCost)+fromPortal)
+}
+
+// CostBound returns a cost no path from a to b can go below, the heuristic of A*.
+func CostBound(m *model.GameMap, a model.Node, b model.Node) int32 {
+	return heuristic(m, a, b)
+}
+
+// distanceIndex returns the position of the cell in the distance tables of the map. They
+// only have one level unless the map is a voxel grid, since distance ignores levels.
+func distanceIndex(m *model.GameMap, n model.Node) int32 {
+	if isVoxel(m) {
+		return cellIndex(m, n)
+	}
+
+	return n.Y*m.Width + n.X
+}
+
+// estimate is the heuristic of A* with the options applied.
+func (o Options) estimate(m *model.GameMap, n model.Node, target model.Node) int32 {
+	if o.Heuristic == HeuristicNone {
+		return 0
+	}
+
+	h := heuristic(m, n, target)
+	if o.HeuristicWeight > 0 {
+		return int32(float64(h) * o.HeuristicWeight)
+	}
+
+	return h
+}
+
+// distance is the least number of steps between two cells, taking the shorter way round on
+// wrapped axes. On maps that are not voxel grids it is the Manhattan distance and levels are
+// ignored, they can only be changed through portals and connectors.
+func distance(m *model.GameMap, a model.Node, b model.Node) int32 {
+	dz, dy, dx := deltas(m, a, b)
+	if isVoxel(m) {
+		return voxelDistance(m, dz, dy, dx)
+	}
+
+	return dy + dx
+}
+
+// deltas returns the absolute per-axis distances between two cells.
+func deltas(m *model.GameMap, a model.Node, b model.Node) (dz, dy, dx int32) {
+	dz, dy, dx = abs(a.Z-b.Z), abs(a.Y-b.Y), abs(a.X-b.X)
+	if wrapsY(m) {
+		dy = min(dy, m.Height-dy)
+	}
+
+	if wrapsX(m) {
+		dx = min(dx, m.Width-dx)
+	}
+
+	return dz, dy, dx
+}
+
+// buildPath walks the parent links back from the target and returns the path from start to target.
+func buildPath(parents map[model.Node]model.Node, start model.Node, target model.Node) []*model.Node {
+	var path []*model.Node
+
+	n := target
+	for {
+		node := n
+		path = append(path, &node)
+
+		if n == start {
+			break
+		}
+
+		n = parents[n]
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
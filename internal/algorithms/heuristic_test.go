@@ -0,0 +1,67 @@
+package algorithms
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Stairs from the start's end of the corridor to the upper level and back down next to the
+// target make a shortcut the plain distance doesn't know about.
+func TestAstarTakesConnectorShortcut(t *testing.T) {
+	row := func() [][]int32 { return [][]int32{make([]int32, 10)} }
+
+	m := &model.GameMap{
+		Width:  10,
+		Height: 1,
+		Levels: model.Volume{row(), row()},
+		Connectors: []model.Connector{
+			{Kind: "stairs", Cells: []model.Node{{Z: 0, X: 0}, {Z: 1, X: 9}}, Cost: 1},
+			{Kind: "stairs", Cells: []model.Node{{Z: 1, X: 9}, {Z: 0, X: 9}}, Cost: 1},
+		},
+	}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &model.Player{Start: model.Node{X: 1}, Target: model.Node{X: 9}}
+
+	res := NewAstar(false).Find(context.Background(), *m, p, Options{})
+	if res.Reason != ReasonFound || res.Stats.Cost != 3 {
+		t.Fatalf("got %s with cost %d, want found with cost 3 through the stairs", res.Reason, res.Stats.Cost)
+	}
+}
+
+// The heuristic never estimates more than the cheapest path costs.
+func TestHeuristicIsAdmissible(t *testing.T) {
+	r := rand.New(rand.NewSource(33))
+
+	for i := range 200 {
+		m := randomMap(t, r, mapFeatures{levels: i%2 == 0, portals: true, wrap: i%3 == 0})
+		if i%4 == 1 {
+			m.Connectivity = model.Connectivity26
+			m.Portals = m.Portals[:1]
+			m.Connectors = nil
+			if err := Prepare(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		target := randomWalkable(r, m)
+
+		for range 10 {
+			p := &model.Player{Start: randomWalkable(r, m), Target: target}
+
+			res := (&Dijkstra{}).Find(context.Background(), *m, p, Options{})
+			if res.Reason != ReasonFound {
+				continue
+			}
+
+			if h := heuristic(m, p.Start, target); h > res.Stats.Cost {
+				t.Fatalf("map %d: heuristic %d from %v to %v, the path costs %d", i, h, p.Start, target, res.Stats.Cost)
+			}
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package algorithms
 
-import "github.com/unomns/findpath/internal/model"
+import (
+	"context"
+	"time"
+
+	"github.com/unomns/findpath/internal/model"
+)
 
 type Position struct {
 	X, Y int
@@ -8,5 +13,84 @@ type Position struct {
 
 type PathFinder interface {
 	Name() string
-	Find(m model.GameMap, p *model.Player) []*model.Node
+	Find(ctx context.Context, m model.GameMap, p *model.Player, o Options) *Result
+}
+
+// Options limits how much work a single search may do and tunes A*. Zero values mean
+// unlimited and the plain distance heuristic.
+type Options struct {
+	MaxExpansions int   // max nodes taken from the open list
+	MaxMemory     int64 // approximate bytes held by search nodes
+
+	// A* only. A weight above 1 finds paths faster but they may not be the cheapest, 0 means 1.
+	Heuristic       Heuristic
+	HeuristicWeight float64
+
+	// A* only, ignored on maps with turn rules. A failed search still returns the path to the
+	// expanded cell closest to the target.
+	PartialPaths bool
+}
+
+// Heuristic is the estimate A* adds to the cost of reaching a cell.
+type Heuristic int
+
+const (
+	HeuristicDistance Heuristic = iota // least steps to the target, portals included
+	HeuristicNone                      // A* expands cells in the same order as Dijkstra
+)
+
+type StopReason int
+
+const (
+	ReasonFound StopReason = iota
+	ReasonNoPath
+	ReasonCancelled
+	ReasonBudgetExceeded
+	ReasonDifferentRegion // start and target are in different components, no search was run
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case ReasonFound:
+		return "found"
+	case ReasonNoPath:
+		return "no_path"
+	case ReasonCancelled:
+		return "cancelled"
+	case ReasonBudgetExceeded:
+		return "budget_exceeded"
+	case ReasonDifferentRegion:
+		return "different_region"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats describes the work done by one search.
+type Stats struct {
+	Cost      int32 // total cost of the found path, 0 if there is none
+	Expanded  int
+	Generated int
+	PeakOpen  int // max size of the open list
+	Duration  time.Duration
+}
+
+type Result struct {
+	Path   []*model.Node // partial when Reason isn't ReasonFound, see Options.PartialPaths
+	Reason StopReason
+	Stats  Stats
+	Routes []*Route // ranked alternatives, best first; only set by KShortestPaths
+}
+
+func found(path []*model.Node, s Stats) *Result {
+	return &Result{Path: path, Reason: ReasonFound, Stats: s}
+}
+
+func stopped(r StopReason, s Stats) *Result {
+	return &Result{Reason: r, Stats: s}
+}
+
+// partial is a failed result that may still carry the path to the cell closest to the target.
+func partial(path []*model.Node, r StopReason, s Stats) *Result {
+	return &Result{Path: path, Reason: r, Stats: s}
 }
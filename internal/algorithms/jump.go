@@ -0,0 +1,467 @@
+package algorithms
+
+import (
+	"math/bits"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Jump search is A* for 26-connected voxel grids where every move costs one step. Many
+// equally short paths lead to a cell there, and it only follows the canonical one: the path changing the
+// fewest coordinates in total, taking the moves that change more of them first. A move is
+// skipped when the cell before reaches the next cell without it, in fewer moves or by a path
+// that comes first. Straight moves are followed until another move becomes worth taking, the
+// cells in between never go on the open list.
+//
+// Moves are numbered by bitOf, the way Moves stores them.
+
+// startMove enters the start cell, every move is worth taking from there.
+const startMove = 13 // bitOf(0, 0, 0)
+
+// moveOffsets holds the dz, dy, dx of every move.
+var moveOffsets = func() (res [27][3]int32) {
+	for b := range res {
+		res[b] = [3]int32{int32(b/9 - 1), int32(b/3%3 - 1), int32(b%3 - 1)}
+	}
+
+	return res
+}()
+
+// moveAxes returns how many coordinates the move changes.
+func moveAxes(b int32) int32 {
+	o := moveOffsets[b]
+
+	return abs(o[0]) + abs(o[1]) + abs(o[2])
+}
+
+// jumpable reports whether jump search works on the map: a 26-connected voxel grid without
+// cost layers, portals, connectors or wrapped edges. With fewer neighbours, canonical paths
+// turn too often for straight moves to go far.
+func jumpable(m *model.GameMap) bool {
+	return m.Connectivity == model.Connectivity26 && m.ExtraCost == nil && len(m.PortalsFrom) == 0 && m.Wrap == model.WrapNone
+}
+
+// canStep reports whether move b from n is allowed.
+func canStep(m *model.GameMap, n model.Node, b int32) bool {
+	if m.Moves != nil {
+		return m.Moves[cellIndex(m, n)]&(1<<b) != 0
+	}
+
+	axes := moveAxes(b)
+	if axes == 0 || int(axes) > maxAxes(m) {
+		return false
+	}
+
+	o := moveOffsets[b]
+	to, ok := voxelOffset(m, n, o[0], o[1], o[2])
+
+	return ok && walkable(m, to) && (axes == 1 || clearCorners(m, n, o[0], o[1], o[2]))
+}
+
+// legalMoves returns the moves allowed from n, one bit per move.
+func legalMoves(m *model.GameMap, n model.Node) uint32 {
+	if m.Moves != nil {
+		return m.Moves[cellIndex(m, n)]
+	}
+
+	var res uint32
+	for b := range int32(27) {
+		if canStep(m, n, b) {
+			res |= 1 << b
+		}
+	}
+
+	return res
+}
+
+// canonicalMoves returns the moves worth taking from z after entering it by move d.
+func canonicalMoves(m *model.GameMap, z model.Node, d int32) uint32 {
+	if d == startMove {
+		return legalMoves(m, z)
+	}
+
+	o := moveOffsets[d]
+	p := model.Node{Z: z.Z - o[0], Y: z.Y - o[1], X: z.X - o[2]}
+
+	if moveAxes(d) == 1 {
+		return straightMoves(m, p, z, d, sides(m, p, d), sides(m, z, d))
+	}
+
+	return jumpRules[d].moves(m, p)
+}
+
+// straightMoves is canonicalMoves for the straight move d from p to z, given the walkable
+// cells beside p and z. When every cell beside z is walkable beside
+// p too, diagonal moves from p reach everything z could turn to, so only going straight on is
+// worth it.
+func straightMoves(m *model.GameMap, p model.Node, z model.Node, d int32, beside uint8, here uint8) uint32 {
+	if here&^beside != 0 {
+		return jumpRules[d].moves(m, p)
+	}
+
+	if canStep(m, z, d) {
+		return 1 << d
+	}
+
+	return 0
+}
+
+// sideOffsets holds the offsets of the cells beside every straight move, across it.
+var sideOffsets = func() (res [27][8][3]int32) {
+	for d := range int32(27) {
+		if moveAxes(d) != 1 {
+			continue
+		}
+
+		o, i := moveOffsets[d], 0
+		for _, s := range moveOffsets {
+			if s != [3]int32{} && s[0]*o[0]+s[1]*o[1]+s[2]*o[2] == 0 {
+				res[d][i] = s
+				i++
+			}
+		}
+	}
+
+	return res
+}()
+
+// sides returns which cells beside n across the straight move d are walkable, one bit each.
+func sides(m *model.GameMap, n model.Node, d int32) uint8 {
+	var res uint8
+	for i, s := range sideOffsets[d] {
+		c := model.Node{Z: n.Z + s[0], Y: n.Y + s[1], X: n.X + s[2]}
+		if inBounds(m, c) && walkable(m, c) {
+			res |= 1 << i
+		}
+	}
+
+	return res
+}
+
+// box is a set of cells around the cell p a move d came from, up to two steps away on each
+// axis. Bit boxBit(dz, dy, dx) is the cell at that offset from p.
+type box [2]uint64
+
+func boxBit(dz, dy, dx int32) int32 {
+	return (dz+2)*25 + (dy+2)*5 + dx + 2
+}
+
+func (b *box) add(bit int32) {
+	b[bit/64] |= 1 << (bit % 64)
+}
+
+// within reports whether all cells of b are in free.
+func (b box) within(free box) bool {
+	return b[0]&^free[0] == 0 && b[1]&^free[1] == 0
+}
+
+// moveRules tell the moves worth taking after a move d from the walkable cells around p.
+type moveRules struct {
+	cells []int32 // the box bits the rules look at
+	next  uint32  // the moves the rules are for
+
+	need [27]box   // cells that have to be walkable for each move on
+	ways [27][]box // for each move on, the ways from p that come first, any walkable one skips it
+}
+
+// jumpRules holds the rules for every move.
+var jumpRules = func() (res [27]moveRules) {
+	for d := range int32(27) {
+		if d != startMove {
+			res[d] = newMoveRules(d)
+		}
+	}
+
+	return res
+}()
+
+func newMoveRules(d int32) moveRules {
+	var r moveRules
+
+	// steps returns the cells the move b from the cell at offset from of p passes through.
+	steps := func(from [3]int32, b int32) box {
+		var res box
+
+		o := moveOffsets[b]
+		for mask := 1; mask < 8; mask++ {
+			s := [3]int32{o[0] * int32(mask&1), o[1] * int32(mask>>1&1), o[2] * int32(mask>>2&1)}
+			if s != [3]int32{} {
+				res.add(boxBit(from[0]+s[0], from[1]+s[1], from[2]+s[2]))
+			}
+		}
+
+		return res
+	}
+
+	od := moveOffsets[d]
+	for mv := range int32(27) {
+		if mv == startMove {
+			continue
+		}
+
+		r.next |= 1 << mv
+		r.need[mv] = steps(od, mv)
+
+		om := moveOffsets[mv]
+		t := [3]int32{od[0] + om[0], od[1] + om[1], od[2] + om[2]}
+
+		if t == [3]int32{} {
+			r.ways[mv] = []box{{}} // back to p
+			continue
+		}
+
+		if abs(t[0]) <= 1 && abs(t[1]) <= 1 && abs(t[2]) <= 1 {
+			r.ways[mv] = append(r.ways[mv], steps([3]int32{}, bitOf(t[0], t[1], t[2])))
+		}
+
+		for a := range int32(27) {
+			oa := moveOffsets[a]
+			b := [3]int32{t[0] - oa[0], t[1] - oa[1], t[2] - oa[2]}
+			if a == d || a == startMove || b == [3]int32{} || abs(b[0]) > 1 || abs(b[1]) > 1 || abs(b[2]) > 1 {
+				continue
+			}
+
+			if bb := bitOf(b[0], b[1], b[2]); comesFirst(a, bb, d, mv) {
+				way := steps([3]int32{}, a)
+				second := steps(oa, bb)
+
+				r.ways[mv] = append(r.ways[mv], box{way[0] | second[0], way[1] | second[1]})
+			}
+		}
+	}
+
+	var all box
+	for mv := range r.need {
+		all[0], all[1] = all[0]|r.need[mv][0], all[1]|r.need[mv][1]
+		for _, w := range r.ways[mv] {
+			all[0], all[1] = all[0]|w[0], all[1]|w[1]
+		}
+	}
+
+	for bit := range int32(125) {
+		if all[bit/64]&(1<<(bit%64)) != 0 {
+			r.cells = append(r.cells, bit)
+		}
+	}
+
+	return r
+}
+
+// moves applies the rules around p.
+func (r *moveRules) moves(m *model.GameMap, p model.Node) uint32 {
+	var free box
+	for _, bit := range r.cells {
+		n := model.Node{Z: p.Z + bit/25 - 2, Y: p.Y + bit/5%5 - 2, X: p.X + bit%5 - 2}
+		if inBounds(m, n) && walkable(m, n) {
+			free.add(bit)
+		}
+	}
+
+	var res uint32
+
+next:
+	for moves := r.next; moves != 0; moves &= moves - 1 {
+		mv := int32(bits.TrailingZeros32(moves))
+		if !r.need[mv].within(free) {
+			continue
+		}
+
+		for _, w := range r.ways[mv] {
+			if w.within(free) {
+				continue next
+			}
+		}
+
+		res |= 1 << mv
+	}
+
+	return res
+}
+
+// comesFirst reports whether the moves a then b come before c then d in the canonical order:
+// fewer coordinates changed in total, then the first move changing more of them.
+func comesFirst(a, b, c, d int32) bool {
+	if x, y := moveAxes(a)+moveAxes(b), moveAxes(c)+moveAxes(d); x != y {
+		return x < y
+	}
+
+	if a != c {
+		return moveRank(a) < moveRank(c)
+	}
+
+	return moveRank(b) < moveRank(d)
+}
+
+// moveRank orders the moves, the ones changing more coordinates first.
+func moveRank(b int32) int32 {
+	return (3-moveAxes(b))*27 + b
+}
+
+// jump follows the straight move d from z until another move is worth taking or the target
+// is reached. ok is false when the way ends in a wall.
+func jump(m *model.GameMap, z model.Node, d int32, target model.Node) (to model.Node, steps int32, ok bool) {
+	o := moveOffsets[d]
+	beside := sides(m, z, d)
+
+	for {
+		p := z
+		z = model.Node{Z: z.Z + o[0], Y: z.Y + o[1], X: z.X + o[2]}
+		steps++
+
+		if z == target {
+			return z, steps, true
+		}
+
+		here := sides(m, z, d)
+		next := straightMoves(m, p, z, d, beside, here)
+		beside = here
+
+		if next&^(1<<d) != 0 {
+			return z, steps, true
+		}
+
+		if next == 0 {
+			return z, steps, false
+		}
+	}
+}
+
+// jumpSearch is search on jumpable maps. A cell may be entered at the same cost by moves that
+// allow different moves on, so it is expanded again for a move found after it was expanded.
+func (a *Astar) jumpSearch(m *model.GameMap, start model.Node, target model.Node, bgt *budget) ([]*model.Node, int32, StopReason) {
+	s := acquireCellState(int(m.Width * m.Height * m.Depth()))
+	defer releaseCellState(s)
+
+	s.growJumps()
+
+	goal := cellIndex(m, target)
+	first := cellIndex(m, start)
+
+	best, bestH := first, heuristic(m, start, target)
+
+	s.enter(first, 0, -1, startMove)
+	s.open.push(cellItem{f: bgt.opts.estimate(m, start, target), cell: first})
+	bgt.generate(1)
+
+	for len(s.open) > 0 {
+		bgt.open(len(s.open))
+
+		current := s.open.pop()
+
+		pending := s.entered[current.cell] &^ s.expanded[current.cell]
+		if pending == 0 {
+			continue // stale entry, the cell was already expanded for its moves
+		}
+
+		if reason, ok := bgt.expand(); !ok {
+			path, cost := s.jumpPartial(m, best, bgt.opts)
+
+			return path, cost, reason
+		}
+
+		node := cellNode(m, current.cell)
+		if bgt.opts.PartialPaths {
+			if h := heuristic(m, node, target); h < bestH {
+				best, bestH = current.cell, h
+			}
+		}
+
+		g := s.cost[current.cell]
+		if current.cell == goal {
+			return s.jumpPath(m, goal), g, ReasonFound
+		}
+
+		s.expanded[current.cell] |= pending
+
+		var moves uint32
+		for ; pending != 0; pending &= pending - 1 {
+			moves |= canonicalMoves(m, node, int32(bits.TrailingZeros32(pending)))
+		}
+
+		for ; moves != 0; moves &= moves - 1 {
+			mv := int32(bits.TrailingZeros32(moves))
+
+			o := moveOffsets[mv]
+			to, steps, ok := model.Node{Z: node.Z + o[0], Y: node.Y + o[1], X: node.X + o[2]}, int32(1), true
+			if moveAxes(mv) == 1 {
+				to, steps, ok = jump(m, node, mv, target)
+			}
+
+			if !ok {
+				continue
+			}
+
+			n := cellIndex(m, to)
+			if s.enter(n, g+steps, current.cell, mv) {
+				s.open.push(cellItem{f: g + steps + bgt.opts.estimate(m, to, target), g: g + steps, cell: n})
+				bgt.generate(1)
+			}
+		}
+	}
+
+	path, cost := s.jumpPartial(m, best, bgt.opts)
+
+	return path, cost, ReasonNoPath
+}
+
+// growJumps makes room for the per-cell data only jump search uses.
+func (s *cellState) growJumps() {
+	if len(s.entered) < len(s.reached) {
+		s.entered = make([]uint32, len(s.reached))
+		s.expanded = make([]uint32, len(s.reached))
+		s.via = make([]uint8, len(s.reached))
+	}
+}
+
+// enter records that move mv, repeated from parent, enters the cell at the given cost. It
+// reports whether the cell has to go on the open list for it.
+func (s *cellState) enter(cell int32, cost int32, parent int32, mv int32) bool {
+	if s.reached[cell] != s.gen || (cost < s.cost[cell] && s.expanded[cell] == 0) {
+		s.reach(cell, cost, parent)
+		s.entered[cell], s.expanded[cell], s.via[cell] = 1<<mv, 0, uint8(mv)
+
+		return true
+	}
+
+	if cost != s.cost[cell] || s.entered[cell]&(1<<mv) != 0 {
+		return false
+	}
+
+	s.entered[cell] |= 1 << mv
+
+	// an entry still on the open list expands the new move too
+	return s.expanded[cell] != 0
+}
+
+// jumpPath is path for jump search, filling in the cells jumped over. Every move costs one
+// step, so the path has one node more than the cost.
+func (s *cellState) jumpPath(m *model.GameMap, goal int32) []*model.Node {
+	n := s.cost[goal] + 1
+
+	nodes := make([]model.Node, n)
+	path := make([]*model.Node, n)
+
+	c, at := goal, cellNode(m, goal)
+	for i := n - 1; i >= 0; i-- {
+		nodes[i] = at
+		path[i] = &nodes[i]
+
+		o := moveOffsets[s.via[c]]
+		at = model.Node{Z: at.Z - o[0], Y: at.Y - o[1], X: at.X - o[2]}
+
+		if i > 0 && cellIndex(m, at) == s.parent[c] {
+			c = s.parent[c]
+		}
+	}
+
+	return path
+}
+
+// jumpPartial is partial for jump search.
+func (s *cellState) jumpPartial(m *model.GameMap, best int32, o Options) ([]*model.Node, int32) {
+	if !o.PartialPaths {
+		return nil, 0
+	}
+
+	return s.jumpPath(m, best), s.cost[best]
+}
@@ -0,0 +1,223 @@
+package algorithms
+
+import (
+	"context"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// randomVolume returns a prepared voxel grid with the given share of blocked voxels.
+func randomVolume(t testing.TB, r *rand.Rand, size int32, blocked float64, c model.Connectivity) *model.GameMap {
+	t.Helper()
+
+	m := &model.GameMap{Width: size, Height: size, Levels: make(model.Volume, size), Connectivity: c}
+	for z := range m.Levels {
+		m.Levels[z] = make([][]int32, size)
+		for y := range m.Levels[z] {
+			m.Levels[z][y] = make([]int32, size)
+			for x := range m.Levels[z][y] {
+				if r.Float64() < blocked {
+					m.Levels[z][y][x] = 1
+				}
+			}
+		}
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// Jump search finds paths as cheap as Dijkstra's, and walking them costs what it reports.
+func TestJumpsMatchDijkstra(t *testing.T) {
+	for _, compiled := range []bool{false, true} {
+		r := rand.New(rand.NewSource(34))
+
+		for i := range 500 {
+			m := randomVolume(t, r, 3+r.Int31n(5), r.Float64()*0.4, model.Connectivity26)
+			if compiled {
+				PrepareMoves(m)
+			}
+
+			if !jumpable(m) {
+				t.Fatal("a plain volume is not searched with jumps")
+			}
+
+			p := &model.Player{Start: randomWalkable(r, m), Target: randomWalkable(r, m)}
+
+			want := (&Dijkstra{}).Find(context.Background(), *m, p, Options{})
+			got := NewJumpPoint(false).Find(context.Background(), *m, p, Options{})
+			if got.Reason != want.Reason || got.Stats.Cost != want.Stats.Cost {
+				t.Fatalf("map %d: jumps %s with cost %d, dijkstra %s with cost %d", i, got.Reason, got.Stats.Cost, want.Reason, want.Stats.Cost)
+			}
+
+			if got.Reason == ReasonFound {
+				if cost := followCost(t, m, p, got.Path); cost != got.Stats.Cost {
+					t.Fatalf("map %d: cost %d, walking the path costs %d: %v", i, got.Stats.Cost, cost, nodes(got.Path))
+				}
+			}
+		}
+	}
+}
+
+// The precomputed rules, and the shortcut for straight moves, keep the moves that checking
+// every way round them keeps.
+func TestJumpRules(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+
+	for range 100 {
+		m := randomVolume(t, r, 5, r.Float64()*0.4, model.Connectivity26)
+
+		forEachCell(m, func(z model.Node) {
+			if !walkable(m, z) {
+				return
+			}
+
+			for d := range int32(27) {
+				o := moveOffsets[d]
+				p := model.Node{Z: z.Z - o[0], Y: z.Y - o[1], X: z.X - o[2]}
+				if d == startMove || !inBounds(m, p) || !canStep(m, p, d) {
+					continue
+				}
+
+				var want uint32
+				for moves := legalMoves(m, z); moves != 0; moves &= moves - 1 {
+					if mv := int32(bits.TrailingZeros32(moves)); !dominated(m, p, d, mv) {
+						want |= 1 << mv
+					}
+				}
+
+				if got := canonicalMoves(m, z, d); got != want {
+					t.Fatalf("%v entered by %v: moves %027b, want %027b", z, o, got, want)
+				}
+			}
+		})
+	}
+}
+
+// dominated reports whether the moves d then mv from p are not the canonical way to the cell
+// they lead to, trying every way there.
+func dominated(m *model.GameMap, p model.Node, d int32, mv int32) bool {
+	od, om := moveOffsets[d], moveOffsets[mv]
+	t := [3]int32{od[0] + om[0], od[1] + om[1], od[2] + om[2]}
+
+	if t == [3]int32{} {
+		return true
+	}
+
+	if abs(t[0]) <= 1 && abs(t[1]) <= 1 && abs(t[2]) <= 1 && canStep(m, p, bitOf(t[0], t[1], t[2])) {
+		return true
+	}
+
+	for a := range int32(27) {
+		if a == d || !canStep(m, p, a) {
+			continue
+		}
+
+		oa := moveOffsets[a]
+		q := model.Node{Z: p.Z + oa[0], Y: p.Y + oa[1], X: p.X + oa[2]}
+
+		for b := range int32(27) {
+			ob := moveOffsets[b]
+			if oa[0]+ob[0] == t[0] && oa[1]+ob[1] == t[1] && oa[2]+ob[2] == t[2] && canStep(m, q, b) && comesFirst(a, b, d, mv) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// wallsVolume returns an open 26-connected volume cut by walls across x, each with a hole in
+// an opposite corner.
+func wallsVolume(t testing.TB, size int32) *model.GameMap {
+	m := randomVolume(t, rand.New(rand.NewSource(34)), size, 0, model.Connectivity26)
+
+	for i, x := range []int32{size / 4, size / 2, size * 3 / 4} {
+		for z := range size {
+			for y := range size {
+				m.Levels[z][y][x] = 1
+			}
+		}
+
+		hole := 1 + int32(i%2)*(size-3)
+		m.Levels[hole][hole][x] = 0
+	}
+
+	return m
+}
+
+// Open space is jumped over: A* expands most of the volume to get through the holes of the
+// walls, jump search only a few cells around them.
+func TestJumpsExpandLess(t *testing.T) {
+	m := wallsVolume(t, 32)
+	p := &model.Player{Start: model.Node{Z: 16, Y: 16}, Target: model.Node{Z: 16, Y: 16, X: 31}}
+
+	for _, o := range []Options{{}, {Heuristic: HeuristicNone}} {
+		jumps := NewJumpPoint(false).Find(context.Background(), *m, p, o)
+		astar := NewAstar(false).Find(context.Background(), *m, p, o)
+
+		if jumps.Reason != ReasonFound || jumps.Stats.Cost != astar.Stats.Cost {
+			t.Fatalf("jumps %s with cost %d, A* %s with cost %d", jumps.Reason, jumps.Stats.Cost, astar.Reason, astar.Stats.Cost)
+		}
+
+		if jumps.Stats.Expanded*10 > astar.Stats.Expanded {
+			t.Errorf("heuristic %d: %d expansions with jumps, %d without", o.Heuristic, jumps.Stats.Expanded, astar.Stats.Expanded)
+		}
+	}
+}
+
+// A failed search returns the path to the closest expanded cell, jumped over cells included.
+func TestJumpsPartialPath(t *testing.T) {
+	m := randomVolume(t, rand.New(rand.NewSource(34)), 6, 0, model.Connectivity26)
+	for y := range m.Height {
+		for x := range m.Width {
+			m.Levels[4][y][x] = 1 // a floor between the start and the target
+		}
+	}
+
+	p := &model.Player{Target: model.Node{Z: 5, Y: 2, X: 3}}
+
+	res := NewJumpPoint(false).Find(context.Background(), *m, p, Options{PartialPaths: true})
+	if res.Reason != ReasonNoPath || len(res.Path) == 0 {
+		t.Fatalf("got %s with %d nodes, want no_path with a partial path", res.Reason, len(res.Path))
+	}
+
+	if last := *res.Path[len(res.Path)-1]; last.Z != 3 {
+		t.Errorf("partial path ends at %v, want below the floor", last)
+	}
+
+	if cost := followCost(t, m, p, res.Path); cost != res.Stats.Cost {
+		t.Errorf("cost %d, walking the path costs %d: %v", res.Stats.Cost, cost, nodes(res.Path))
+	}
+}
+
+func benchVolume(b *testing.B, a *Astar) {
+	m := wallsVolume(b, 64)
+	p := &model.Player{Start: model.Node{Z: 32, Y: 32}, Target: model.Node{Z: 32, Y: 32, X: 63}}
+	ctx := context.Background()
+
+	if res := a.Find(ctx, *m, p, Options{}); res.Reason != ReasonFound {
+		b.Fatalf("no path on the benchmark volume: %s", res.Reason)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		a.Find(ctx, *m, p, Options{})
+	}
+}
+
+func BenchmarkAstarVolume64(b *testing.B) {
+	benchVolume(b, NewAstar(false))
+}
+
+func BenchmarkJumpPointVolume64(b *testing.B) {
+	benchVolume(b, NewJumpPoint(false))
+}
@@ -0,0 +1,149 @@
+package algorithms
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// mapFeatures picks what randomMap puts on a map besides walls.
+type mapFeatures struct {
+	levels  bool // two levels joined by connectors
+	portals bool
+	exits   bool
+	turns   bool // a turn cost, sometimes a turn limit
+	wrap    bool
+	costs   bool // a dense cost layer
+}
+
+// randomMap returns a small prepared map with about a quarter of its cells blocked.
+func randomMap(t testing.TB, r *rand.Rand, f mapFeatures) *model.GameMap {
+	t.Helper()
+
+	m := &model.GameMap{Width: 4 + r.Int31n(5), Height: 3 + r.Int31n(4)}
+
+	depth := int32(1)
+	if f.levels {
+		depth = 2
+	}
+
+	for range depth {
+		level := make([][]int32, m.Height)
+		for y := range level {
+			level[y] = make([]int32, m.Width)
+			for x := range level[y] {
+				if r.Intn(4) == 0 {
+					level[y][x] = 1
+				}
+			}
+		}
+
+		m.Levels = append(m.Levels, level)
+	}
+
+	if f.levels {
+		for range 1 + r.Intn(2) {
+			from, to := randomWalkable(r, m), randomWalkable(r, m)
+			from.Z, to.Z = 0, 1
+			m.Levels[0][from.Y][from.X], m.Levels[1][to.Y][to.X] = 0, 0
+			m.Connectors = append(m.Connectors, model.Connector{Cells: []model.Node{from, to}, Cost: 1 + r.Int31n(3)})
+		}
+	}
+
+	if f.portals {
+		for range 1 + r.Intn(2) {
+			m.Portals = append(m.Portals, model.Portal{From: randomWalkable(r, m), To: randomWalkable(r, m), Cost: r.Int31n(5)})
+		}
+	}
+
+	if f.exits {
+		dirs := []model.Direction{model.DirectionUp, model.DirectionDown, model.DirectionLeft, model.DirectionRight}
+
+		for range 2 + r.Intn(4) {
+			n := randomWalkable(r, m)
+			e := model.CellExits{Y: n.Y, X: n.X, Z: n.Z}
+			for _, d := range dirs {
+				if r.Intn(2) == 0 {
+					e.Allow = append(e.Allow, d)
+				}
+			}
+
+			m.Exits = append(m.Exits, e)
+		}
+	}
+
+	if f.turns {
+		m.TurnCost = 1 + r.Int31n(3)
+		if r.Intn(2) == 0 {
+			m.MaxTurns = 2 + r.Int31n(5)
+		}
+	}
+
+	if f.wrap {
+		m.Wrap = []model.Wrap{model.WrapNone, model.WrapHorizontal, model.WrapVertical, model.WrapBoth}[r.Intn(4)]
+	}
+
+	if f.costs {
+		dense := make([]int32, depth*m.Width*m.Height)
+		for i := range dense {
+			dense[i] = r.Int31n(4)
+		}
+
+		m.CostLayers = []model.CostLayer{{Name: "mud", Dense: dense}}
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// randomWalkable returns a walkable cell of the map, or any cell when they are all blocked.
+func randomWalkable(r *rand.Rand, m *model.GameMap) model.Node {
+	depth := int32(max(1, len(m.Levels)))
+
+	var n model.Node
+	for range 100 {
+		n = model.Node{Z: r.Int31n(depth), Y: r.Int31n(m.Height), X: r.Int31n(m.Width)}
+		if m.Levels[n.Z][n.Y][n.X] == 0 {
+			break
+		}
+	}
+
+	return n
+}
+
+// followCost returns the cost of the cheapest moves along the path, found by a search only
+// allowed to take the steps of the path.
+func followCost(t testing.TB, m *model.GameMap, p *model.Player, path []*model.Node) int32 {
+	t.Helper()
+
+	steps := make(map[[2]model.Node]bool, len(path))
+	for i := 1; i < len(path); i++ {
+		steps[[2]model.Node{*path[i-1], *path[i]}] = true
+	}
+
+	bgt := newBudget(context.Background(), Options{}, 0)
+	start := turnState{node: *path[0], heading: toDir(p.Heading)}
+
+	res, cost, reason := dijkstraSearch(m, start, *path[len(path)-1], bgt, func(from, to model.Node) bool {
+		return steps[[2]model.Node{from, to}]
+	})
+	if reason != ReasonFound || len(res) != len(path) {
+		t.Fatalf("the moves can't follow path %v: %s", nodes(path), reason)
+	}
+
+	return cost
+}
+
+func nodes(path []*model.Node) []model.Node {
+	res := make([]model.Node, len(path))
+	for i, n := range path {
+		res[i] = *n
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+type rect struct {
+	x, y, w, h int32
+}
+
+// GenerateNavMesh turns the walkable cells of one level into a prepared navmesh. Cells are merged
+// greedily into maximal rectangles, cell [y x] covering the square from (x, y) to (x+1, y+1).
+// Only walkability is used: cost layers, portals, exits and wrapping are not carried over.
+func GenerateNavMesh(m *model.GameMap, z int32) (*model.NavMesh, error) {
+	if z < 0 || z >= m.Depth() {
+		return nil, fmt.Errorf("unknown level %d", z)
+	}
+
+	rects := mergeCells(m, z)
+
+	mesh := &model.NavMesh{}
+	index := make(map[model.Point]int32)
+	byX := make(map[float64][]int32)
+	byY := make(map[float64][]int32)
+
+	vertex := func(x, y int32) {
+		p := model.Point{X: float64(x), Y: float64(y)}
+		if _, ok := index[p]; ok {
+			return
+		}
+
+		i := int32(len(mesh.Vertices))
+		index[p] = i
+		mesh.Vertices = append(mesh.Vertices, p)
+		byX[p.X] = append(byX[p.X], i)
+		byY[p.Y] = append(byY[p.Y], i)
+	}
+
+	for _, r := range rects {
+		vertex(r.x, r.y)
+		vertex(r.x+r.w, r.y)
+		vertex(r.x+r.w, r.y+r.h)
+		vertex(r.x, r.y+r.h)
+	}
+
+	// every vertex lying on a side becomes part of the outline, so neighbouring rectangles
+	// share whole edges even when their corners don't line up
+	px := func(p model.Point) float64 { return p.X }
+	py := func(p model.Point) float64 { return p.Y }
+
+	for _, r := range rects {
+		x0, y0, x1, y1 := r.x, r.y, r.x+r.w, r.y+r.h
+
+		var poly []int32
+		poly = append(poly, onSide(mesh, byY[float64(y0)], px, x0, x1, false)...) // top, left to right
+		poly = append(poly, onSide(mesh, byX[float64(x1)], py, y0, y1, false)...) // right, downwards
+		poly = append(poly, onSide(mesh, byY[float64(y1)], px, x0, x1, true)...)  // bottom, right to left
+		poly = append(poly, onSide(mesh, byX[float64(x0)], py, y0, y1, true)...)  // left, upwards
+
+		mesh.Polygons = append(mesh.Polygons, poly)
+	}
+
+	if err := PrepareNavMesh(mesh); err != nil {
+		return nil, err
+	}
+
+	return mesh, nil
+}
+
+// onSide picks the vertices of one rectangle side, sorted along it. Each side includes the corner
+// it starts at and leaves out the one it ends at: from lo up to hi, or from hi down to lo when desc.
+func onSide(mesh *model.NavMesh, vertices []int32, coord func(model.Point) float64, lo, hi int32, desc bool) []int32 {
+	var res []int32
+	for _, v := range vertices {
+		c := coord(mesh.Vertices[v])
+		if (!desc && c >= float64(lo) && c < float64(hi)) || (desc && c > float64(lo) && c <= float64(hi)) {
+			res = append(res, v)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b int32) int {
+		return cmp.Compare(coord(mesh.Vertices[a]), coord(mesh.Vertices[b]))
+	})
+
+	if desc {
+		slices.Reverse(res)
+	}
+
+	return res
+}
+
+// mergeCells covers the walkable cells of a level with rectangles, taking the widest run of
+// free cells in a row first and growing it down while the rows below are free as well.
+func mergeCells(m *model.GameMap, z int32) []rect {
+	used := make([]bool, m.Width*m.Height)
+	free := func(y, x int32) bool {
+		return !used[y*m.Width+x] && walkable(m, model.Node{Y: y, X: x, Z: z})
+	}
+
+	var rects []rect
+
+	for y := range m.Height {
+		for x := range m.Width {
+			if !free(y, x) {
+				continue
+			}
+
+			r := rect{x: x, y: y, w: 1, h: 1}
+			for r.x+r.w < m.Width && free(y, r.x+r.w) {
+				r.w++
+			}
+
+		grow:
+			for r.y+r.h < m.Height {
+				for cx := r.x; cx < r.x+r.w; cx++ {
+					if !free(r.y+r.h, cx) {
+						break grow
+					}
+				}
+
+				r.h++
+			}
+
+			for cy := r.y; cy < r.y+r.h; cy++ {
+				for cx := r.x; cx < r.x+r.w; cx++ {
+					used[cy*m.Width+cx] = true
+				}
+			}
+
+			rects = append(rects, r)
+		}
+	}
+
+	return rects
+}
@@ -0,0 +1,131 @@
+package algorithms
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// uMap is a 6×6 level with a hole left of x = 4 between y = 2 and y = 4, the grid version of
+// navmesh.example.json.
+func uMap(t *testing.T) *model.GameMap {
+	t.Helper()
+
+	m := openMap(t, 6, 6)
+	for y := 2; y < 4; y++ {
+		for x := range 4 {
+			m.Grid[y][x] = 1
+		}
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// bounds returns the box around a polygon as {x0, y0, x1, y1}.
+func bounds(mesh *model.NavMesh, poly []int32) [4]float64 {
+	b := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, v := range poly {
+		p := mesh.Vertices[v]
+		b = [4]float64{min(b[0], p.X), min(b[1], p.Y), max(b[2], p.X), max(b[3], p.Y)}
+	}
+
+	return b
+}
+
+func TestGenerateNavMesh(t *testing.T) {
+	mesh, err := GenerateNavMesh(uMap(t), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the top row, the right arm and the bottom row left of it
+	want := [][4]float64{{0, 0, 6, 2}, {4, 2, 6, 6}, {0, 4, 4, 6}}
+
+	var got [][4]float64
+	for _, poly := range mesh.Polygons {
+		got = append(got, bounds(mesh, poly))
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("rectangles %v, want %v", got, want)
+	}
+
+	// the arm shares the right part of the bottom of the top row, and the left side of its
+	// lower half with the bottom row, a corner of the bottom row lying on its side
+	type portal struct {
+		polygons [2]int32
+		edge     [2]model.Point
+	}
+
+	wantPortals := []portal{
+		{[2]int32{0, 1}, [2]model.Point{{X: 4, Y: 2}, {X: 6, Y: 2}}},
+		{[2]int32{1, 2}, [2]model.Point{{X: 4, Y: 4}, {X: 4, Y: 6}}},
+	}
+
+	var gotPortals []portal
+	for _, p := range mesh.Portals {
+		a, b := mesh.Vertices[p.Edge[0]], mesh.Vertices[p.Edge[1]]
+		if b.X < a.X || b.Y < a.Y {
+			a, b = b, a
+		}
+
+		gotPortals = append(gotPortals, portal{p.Polygons, [2]model.Point{a, b}})
+	}
+
+	if !slices.Equal(gotPortals, wantPortals) {
+		t.Errorf("portals %v, want %v", gotPortals, wantPortals)
+	}
+
+	if _, err := GenerateNavMesh(uMap(t), 1); err == nil {
+		t.Error("level 1 of a single level map: no error")
+	}
+}
+
+// Blocked cells split rows into several rectangles and the mesh still connects them all.
+func TestGenerateNavMeshCoversWalkableCells(t *testing.T) {
+	m := openMap(t, 5, 4)
+	// 0 0 0 0 0
+	// 0 1 0 1 0
+	// 0 0 0 0 0
+	// 1 0 0 0 1
+	m.Grid[1][1], m.Grid[1][3], m.Grid[3][0], m.Grid[3][4] = 1, 1, 1, 1
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	mesh, err := GenerateNavMesh(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var area float64
+	for _, poly := range mesh.Polygons {
+		b := bounds(mesh, poly)
+		area += (b[2] - b[0]) * (b[3] - b[1])
+
+		for y := b[1]; y < b[3]; y++ {
+			for x := b[0]; x < b[2]; x++ {
+				if m.Grid[int(y)][int(x)] != 0 {
+					t.Errorf("polygon %v covers the blocked cell [%v %v]", b, y, x)
+				}
+			}
+		}
+	}
+
+	if area != 16 {
+		t.Errorf("polygons cover %v cells, want the 16 walkable ones", area)
+	}
+
+	// from the top left to the bottom centre cell
+	p := &model.NavPlayer{Start: model.Point{X: 0.5, Y: 0.5}, Target: model.Point{X: 2.5, Y: 3.5}}
+	if res := FindOnNavMesh(context.Background(), mesh, p, Options{}); res.Reason != ReasonFound {
+		t.Errorf("got %s, want found", res.Reason)
+	}
+}
@@ -0,0 +1,261 @@
+package algorithms
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"unsafe"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// PrepareNavMesh validates the mesh, turns every polygon counter-clockwise and links
+// the polygons sharing an edge. It must be called once per mesh before searching.
+func PrepareNavMesh(mesh *model.NavMesh) error {
+	type edgeKey struct{ a, b int32 }
+	owners := make(map[edgeKey][]int32)
+
+	for i, poly := range mesh.Polygons {
+		if len(poly) < 3 {
+			return fmt.Errorf("polygon %d has less than 3 vertices", i)
+		}
+
+		for _, v := range poly {
+			if v < 0 || int(v) >= len(mesh.Vertices) {
+				return fmt.Errorf("polygon %d uses unknown vertex %d", i, v)
+			}
+		}
+
+		if signedArea(mesh, poly) < 0 {
+			slices.Reverse(poly)
+		}
+
+		if !convex(mesh, poly) {
+			return fmt.Errorf("polygon %d is not convex", i)
+		}
+
+		for k := range poly {
+			a, b := poly[k], poly[(k+1)%len(poly)]
+			key := edgeKey{min(a, b), max(a, b)}
+			owners[key] = append(owners[key], int32(i))
+		}
+	}
+
+	mesh.Links = make([][]model.NavLink, len(mesh.Polygons))
+	mesh.Portals = nil
+
+	for i, poly := range mesh.Polygons {
+		for k := range poly {
+			// the polygon is counter-clockwise, so its inside is on the left of a -> b and
+			// b is on the left when walking out through the edge
+			a, b := poly[k], poly[(k+1)%len(poly)]
+
+			for _, other := range owners[edgeKey{min(a, b), max(a, b)}] {
+				if other == int32(i) {
+					continue
+				}
+
+				mesh.Links[i] = append(mesh.Links[i], model.NavLink{To: other, Left: b, Right: a})
+
+				if int32(i) < other {
+					mesh.Portals = append(mesh.Portals, model.NavPortal{
+						Polygons: [2]int32{int32(i), other},
+						Edge:     [2]int32{a, b},
+					})
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type navItem struct {
+	poly int32
+	g, f float64
+}
+
+type navQueue []navItem
+
+func (q navQueue) Len() int           { return len(q) }
+func (q navQueue) Less(i, j int) bool { return q[i].f < q[j].f }
+func (q navQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *navQueue) Push(x any) { *q = append(*q, x.(navItem)) }
+
+func (q *navQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
+
+// NavResult is the result of a navmesh search. Stats.Cost holds the rounded Length.
+type NavResult struct {
+	Path   []model.Point
+	Length float64
+	Reason StopReason
+	Stats  Stats
+}
+
+const navNodeSize = unsafe.Sizeof(navItem{}) + 3*unsafe.Sizeof(int32(0)) + unsafe.Sizeof(float64(0))
+
+// FindOnNavMesh runs A* over the polygons of a prepared mesh, moving between polygon centres,
+// then pulls the path straight through the crossed edges with the funnel algorithm.
+func FindOnNavMesh(ctx context.Context, mesh *model.NavMesh, p *model.NavPlayer, o Options) *NavResult {
+	bgt := newBudget(ctx, o, navNodeSize)
+	stop := func(r StopReason) *NavResult {
+		return &NavResult{Reason: r, Stats: bgt.stats(0)}
+	}
+
+	start, goal := locate(mesh, p.Start), locate(mesh, p.Target)
+	if start < 0 || goal < 0 {
+		return stop(ReasonNoPath)
+	}
+
+	centre := func(poly int32) model.Point {
+		switch poly {
+		case start:
+			return p.Start
+		case goal:
+			return p.Target
+		default:
+			return centroid(mesh, mesh.Polygons[poly])
+		}
+	}
+
+	costs := map[int32]float64{start: 0}
+	parents := map[int32]model.NavLink{start: {To: -1}}
+	closed := map[int32]bool{}
+	pq := &navQueue{{poly: start, f: dist(p.Start, p.Target)}}
+	bgt.generate(1)
+
+	for pq.Len() > 0 {
+		bgt.open(pq.Len())
+
+		current := heap.Pop(pq).(navItem)
+		if closed[current.poly] {
+			continue
+		}
+
+		if reason, ok := bgt.expand(); !ok {
+			return stop(reason)
+		}
+
+		closed[current.poly] = true
+
+		if current.poly == goal {
+			path := funnel(mesh, p.Start, p.Target, portals(parents, goal))
+			length := polylineLength(path)
+			s := bgt.stats(int32(math.Round(length)))
+
+			return &NavResult{Path: path, Length: length, Reason: ReasonFound, Stats: s}
+		}
+
+		for _, l := range mesh.Links[current.poly] {
+			if closed[l.To] {
+				continue
+			}
+
+			g := current.g + dist(centre(current.poly), centre(l.To))
+			if known, ok := costs[l.To]; ok && known <= g {
+				continue
+			}
+
+			costs[l.To] = g
+			// parents keep the link used to enter a polygon, with To pointing back
+			parents[l.To] = model.NavLink{To: current.poly, Left: l.Left, Right: l.Right}
+			heap.Push(pq, navItem{poly: l.To, g: g, f: g + dist(centre(l.To), p.Target)})
+			bgt.generate(1)
+		}
+	}
+
+	return stop(ReasonNoPath)
+}
+
+// portals returns the edges crossed on the way to the polygon, from the start on.
+func portals(parents map[int32]model.NavLink, poly int32) []model.NavLink {
+	var res []model.NavLink
+	for l := parents[poly]; l.To >= 0; l = parents[l.To] {
+		res = append(res, l)
+	}
+
+	slices.Reverse(res)
+
+	return res
+}
+
+// locate returns the polygon containing the point, -1 if there is none.
+func locate(mesh *model.NavMesh, pt model.Point) int32 {
+	for i, poly := range mesh.Polygons {
+		inside := true
+		for k := range poly {
+			a, b := mesh.Vertices[poly[k]], mesh.Vertices[poly[(k+1)%len(poly)]]
+			if cross(a, b, pt) < 0 {
+				inside = false
+				break
+			}
+		}
+
+		if inside {
+			return int32(i)
+		}
+	}
+
+	return -1
+}
+
+func centroid(mesh *model.NavMesh, poly []int32) model.Point {
+	var c model.Point
+	for _, v := range poly {
+		c.X += mesh.Vertices[v].X
+		c.Y += mesh.Vertices[v].Y
+	}
+
+	return model.Point{X: c.X / float64(len(poly)), Y: c.Y / float64(len(poly))}
+}
+
+func signedArea(mesh *model.NavMesh, poly []int32) float64 {
+	var area float64
+	for k := range poly {
+		a, b := mesh.Vertices[poly[k]], mesh.Vertices[poly[(k+1)%len(poly)]]
+		area += a.X*b.Y - b.X*a.Y
+	}
+
+	return area / 2
+}
+
+func convex(mesh *model.NavMesh, poly []int32) bool {
+	for k := range poly {
+		a := mesh.Vertices[poly[k]]
+		b := mesh.Vertices[poly[(k+1)%len(poly)]]
+		c := mesh.Vertices[poly[(k+2)%len(poly)]]
+
+		if cross(a, b, c) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cross is positive when c lies on the left of the line a -> b.
+func cross(a, b, c model.Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func dist(a, b model.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+func polylineLength(path []model.Point) float64 {
+	var l float64
+	for i := 1; i < len(path); i++ {
+		l += dist(path[i-1], path[i])
+	}
+
+	return l
+}
@@ -0,0 +1,97 @@
+package algorithms
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+func finders() map[string]PathFinder {
+	return map[string]PathFinder{"a-star": NewAstar(false), "dijkstra": &Dijkstra{}, "bfs": &Bfs{}}
+}
+
+// A one-way cell that only lets players out to the left, with a portal running parallel to
+// the step it forbids.
+func TestExitsAndPortals(t *testing.T) {
+	m := &model.GameMap{
+		Width:   4,
+		Height:  1,
+		Grid:    [][]int32{{0, 0, 0, 0}},
+		Portals: []model.Portal{{From: model.Node{X: 1}, To: model.Node{X: 2}, Cost: 5}},
+		Exits:   []model.CellExits{{X: 1, Allow: []model.Direction{model.DirectionLeft}}},
+	}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, f := range finders() {
+		t.Run(name, func(t *testing.T) {
+			res := f.Find(context.Background(), *m, &model.Player{Target: model.Node{X: 3}}, Options{})
+			if res.Reason != ReasonFound {
+				t.Fatalf("reason = %s, want found", res.Reason)
+			}
+
+			// the step from x = 1 to x = 2 is forbidden, the portal costs 5
+			if res.Stats.Cost != 7 {
+				t.Errorf("cost = %d, want 7", res.Stats.Cost)
+			}
+
+			back := f.Find(context.Background(), *m, &model.Player{Start: model.Node{X: 3}}, Options{})
+			if back.Reason != ReasonFound || back.Stats.Cost != 3 {
+				t.Errorf("way back: %s with cost %d, want found with cost 3", back.Reason, back.Stats.Cost)
+			}
+		})
+	}
+}
+
+func TestExitsBlockTheOnlyWay(t *testing.T) {
+	m := &model.GameMap{
+		Width:  3,
+		Height: 1,
+		Grid:   [][]int32{{0, 0, 0}},
+		Exits:  []model.CellExits{{X: 1, Allow: []model.Direction{model.DirectionLeft}}},
+	}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, f := range finders() {
+		if res := f.Find(context.Background(), *m, &model.Player{Target: model.Node{X: 2}}, Options{}); res.Reason != ReasonNoPath {
+			t.Errorf("%s: reason = %s, want no_path", name, res.Reason)
+		}
+	}
+}
+
+// Every searcher reports the cost of the moves along its own path, and A* and Dijkstra find
+// the same cheapest cost.
+func TestPortalsAndExitsCosts(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+
+	for i := range 300 {
+		m := randomMap(t, r, mapFeatures{portals: true, exits: true, costs: i%2 == 0})
+		p := &model.Player{Start: randomWalkable(r, m), Target: randomWalkable(r, m)}
+
+		results := map[string]*Result{}
+		for name, f := range finders() {
+			res := f.Find(context.Background(), *m, p, Options{})
+			results[name] = res
+
+			if res.Reason == ReasonFound {
+				if got := followCost(t, m, p, res.Path); got != res.Stats.Cost {
+					t.Fatalf("map %d, %s: cost %d, walking its path costs %d: %v", i, name, res.Stats.Cost, got, nodes(res.Path))
+				}
+			}
+		}
+
+		a, d, b := results["a-star"], results["dijkstra"], results["bfs"]
+		if a.Reason != d.Reason || b.Reason != d.Reason {
+			t.Fatalf("map %d: a-star %s, dijkstra %s, bfs %s", i, a.Reason, d.Reason, b.Reason)
+		}
+
+		if a.Stats.Cost != d.Stats.Cost {
+			t.Fatalf("map %d: a-star cost %d, dijkstra cost %d", i, a.Stats.Cost, d.Stats.Cost)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// MapError is a part of a map Prepare can't use.
+type MapError struct {
+	Field string // path of the part in the map, e.g. "portals[1]" or "cost_layers[0].cells[3]"
+	Err   error
+}
+
+func (e *MapError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *MapError) Unwrap() error {
+	return e.Err
+}
+
+func mapError(field string, format string, args ...any) error {
+	return &MapError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+// Prepare validates the optional parts of the map and builds the lookup tables the searches
+// use. It must be called once per map before searching. Its errors are MapErrors.
+func Prepare(m *model.GameMap) error {
+	if err := prepareLevels(m); err != nil {
+		return err
+	}
+
+	switch m.Wrap {
+	case model.WrapNone, model.WrapHorizontal, model.WrapVertical, model.WrapBoth:
+	default:
+		return mapError("wrap", "unknown wrap mode %q", m.Wrap)
+	}
+
+	switch m.Connectivity {
+	case model.ConnectivityLevels:
+	case model.Connectivity6, model.Connectivity18, model.Connectivity26:
+		if turnsEnabled(m) || len(m.Exits) > 0 {
+			return mapError("connectivity", "turn rules and exits are not supported with %d-connectivity", m.Connectivity)
+		}
+	default:
+		return mapError("connectivity", "unknown connectivity %d", m.Connectivity)
+	}
+
+	if err := prepareCosts(m); err != nil {
+		return err
+	}
+
+	if err := prepareExits(m); err != nil {
+		return err
+	}
+
+	return PreparePortals(m)
+}
+
+func prepareExits(m *model.GameMap) error {
+	if len(m.Exits) == 0 {
+		m.ExitMask = nil
+		return nil
+	}
+
+	m.ExitMask = make([]uint8, m.Depth()*m.Width*m.Height)
+	for i := range m.ExitMask {
+		m.ExitMask[i] = dirUp.bit() | dirDown.bit() | dirLeft.bit() | dirRight.bit()
+	}
+
+	for i, e := range m.Exits {
+		n := model.Node{Y: e.Y, X: e.X, Z: e.Z}
+		if !inBounds(m, n) {
+			return mapError(fmt.Sprintf("exits[%d]", i), "cell %v is out of the map", n)
+		}
+
+		var mask uint8
+		for k, d := range e.Allow {
+			if toDir(d) == dirNone {
+				return mapError(fmt.Sprintf("exits[%d].allow[%d]", i, k), "unknown direction %q", d)
+			}
+
+			mask |= toDir(d).bit()
+		}
+
+		m.ExitMask[cellIndex(m, n)] = mask
+	}
+
+	return nil
+}
+
+// PreparePortals indexes the portals and the connectors, which are turned into pairs of
+// portals. It fails when one of them starts or ends on a blocked cell.
+func PreparePortals(m *model.GameMap) error {
+	if len(m.Portals) == 0 && len(m.Connectors) == 0 {
+		m.PortalsFrom = nil
+		m.PortalDistance, m.ExitDistance, m.MinPortalCost = nil, nil, 0
+
+		return nil
+	}
+
+	m.PortalsFrom = make(map[model.Node][]model.Portal)
+
+	add := func(p model.Portal, field string) error {
+		if !inBounds(m, p.From) || !inBounds(m, p.To) {
+			return mapError(field, "%v -> %v is out of the map", p.From, p.To)
+		}
+
+		if !walkable(m, p.From) || !walkable(m, p.To) {
+			return mapError(field, "%v -> %v starts or ends on a blocked cell", p.From, p.To)
+		}
+
+		if p.Cost < 0 {
+			return mapError(field, "%v -> %v has negative cost", p.From, p.To)
+		}
+
+		m.PortalsFrom[p.From] = append(m.PortalsFrom[p.From], p)
+
+		return nil
+	}
+
+	for i, p := range m.Portals {
+		if err := add(p, fmt.Sprintf("portals[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range m.Connectors {
+		for _, from := range c.Cells {
+			for _, to := range c.Cells {
+				if from == to {
+					continue
+				}
+
+				cost := c.Cost * max(1, abs(to.Z-from.Z))
+				if err := add(model.Portal{From: from, To: to, Cost: cost}, fmt.Sprintf("connectors[%d]", i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	preparePortalDistances(m)
+
+	return nil
+}
+
+// preparePortalDistances fills the tables that let the heuristic count portals without
+// going through all of them for every cell.
+func preparePortalDistances(m *model.GameMap) {
+	var entries, exits []model.Node
+
+	m.MinPortalCost = math.MaxInt32
+	for from, ps := range m.PortalsFrom {
+		entries = append(entries, from)
+
+		for _, p := range ps {
+			exits = append(exits, p.To)
+			m.MinPortalCost = min(m.MinPortalCost, p.Cost)
+		}
+	}
+
+	m.PortalDistance = stepsFrom(m, entries)
+	m.ExitDistance = stepsFrom(m, exits)
+}
+
+// stepsFrom returns the distance of every cell to the nearest source, walls ignored, indexed
+// by distanceIndex. It is a breadth-first search over the moves distance counts.
+func stepsFrom(m *model.GameMap, sources []model.Node) []int32 {
+	size := m.Width * m.Height
+	if isVoxel(m) {
+		size *= m.Depth()
+	}
+
+	res := make([]int32, size)
+	for i := range res {
+		res[i] = -1
+	}
+
+	queue := make([]model.Node, 0, size)
+
+	visit := func(n model.Node, steps int32) {
+		if i := distanceIndex(m, n); res[i] < 0 {
+			res[i] = steps
+			queue = append(queue, n)
+		}
+	}
+
+	for _, n := range sources {
+		if !isVoxel(m) {
+			n.Z = 0 // levels don't count
+		}
+
+		visit(n, 0)
+	}
+
+	for head := 0; head < len(queue); head++ {
+		n := queue[head]
+		steps := res[distanceIndex(m, n)] + 1
+
+		for dz := int32(-1); dz <= 1; dz++ {
+			for dy := int32(-1); dy <= 1; dy++ {
+				for dx := int32(-1); dx <= 1; dx++ {
+					axes := int(abs(dz) + abs(dy) + abs(dx))
+					if axes == 0 || axes > maxAxes(m) || (dz != 0 && !isVoxel(m)) {
+						continue
+					}
+
+					if to, ok := voxelOffset(m, n, dz, dy, dx); ok {
+						visit(to, steps)
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// prepareLevels makes sure Levels holds every level and Grid points to the first one.
+func prepareLevels(m *model.GameMap) error {
+	if len(m.Levels) == 0 {
+		m.Levels = model.Volume{m.Grid}
+	}
+
+	for z, level := range m.Levels {
+		if int32(len(level)) != m.Height {
+			return mapError(fmt.Sprintf("levels[%d]", z), "%d rows, expected %d", len(level), m.Height)
+		}
+
+		for y, row := range level {
+			if int32(len(row)) != m.Width {
+				return mapError(fmt.Sprintf("levels[%d][%d]", z, y), "%d cells, expected %d", len(row), m.Width)
+			}
+		}
+	}
+
+	m.Grid = m.Levels[0]
+
+	return nil
+}
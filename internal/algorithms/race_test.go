@@ -0,0 +1,6 @@
+//go:build race
+
+package algorithms
+
+// the race detector drops pooled values at random, so pooled searches allocate
+const raceEnabled = true
@@ -0,0 +1,54 @@
+package algorithms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+func TestStats(t *testing.T) {
+	// 0 0 0 0
+	// 1 1 1 0
+	// 0 0 0 0
+	m := &model.GameMap{
+		Width:  4,
+		Height: 3,
+		Grid:   [][]int32{{0, 0, 0, 0}, {1, 1, 1, 0}, {0, 0, 0, 0}},
+	}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, f := range finders() {
+		t.Run(name, func(t *testing.T) {
+			res := f.Find(context.Background(), *m, &model.Player{Target: model.Node{Y: 2}}, Options{})
+			if res.Reason != ReasonFound {
+				t.Fatalf("reason = %s, want found", res.Reason)
+			}
+
+			s := res.Stats
+			if s.Cost != 8 || int(s.Cost) != len(res.Path)-1 {
+				t.Errorf("cost = %d with %d steps, want 8", s.Cost, len(res.Path))
+			}
+
+			// every walkable cell but the target is expanded on the only way round
+			if s.Expanded < 8 || s.Generated < s.Expanded {
+				t.Errorf("%d expanded and %d generated, want at least 8 and at least as many generated", s.Expanded, s.Generated)
+			}
+
+			if s.PeakOpen < 1 || s.PeakOpen > s.Generated {
+				t.Errorf("peak open = %d, want between 1 and %d", s.PeakOpen, s.Generated)
+			}
+
+			if s.Duration <= 0 {
+				t.Errorf("duration = %s", s.Duration)
+			}
+
+			none := f.Find(context.Background(), *m, &model.Player{Target: model.Node{Y: 1}}, Options{})
+			if none.Reason == ReasonFound || none.Stats.Cost != 0 || none.Stats.Expanded == 0 {
+				t.Errorf("into a wall: %s, cost %d after %d expansions, want a cost of 0 after searching", none.Reason, none.Stats.Cost, none.Stats.Expanded)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package algorithms
+
+import (
+	"container/heap"
+	"slices"
+	"unsafe"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+type dir int8
+
+const (
+	dirNone dir = iota
+	dirUp
+	dirDown
+	dirLeft
+	dirRight
+)
+
+// toDir converts the player heading, unknown values are treated as no heading.
+func toDir(d model.Direction) dir {
+	switch d {
+	case model.DirectionUp:
+		return dirUp
+	case model.DirectionDown:
+		return dirDown
+	case model.DirectionLeft:
+		return dirLeft
+	case model.DirectionRight:
+		return dirRight
+	default:
+		return dirNone
+	}
+}
+
+// bit returns the direction flag used in exit masks.
+func (d dir) bit() uint8 {
+	if d == dirNone {
+		return 0
+	}
+
+	return 1 << (d - 1)
+}
+
+// moveDir returns the direction of a step between two adjacent cells. A step across a wrapped
+// edge keeps its direction, e.g. from x = Width-1 to x = 0 is a step right.
+func moveDir(m *model.GameMap, from model.Node, to model.Node) dir {
+	dy, dx := to.Y-from.Y, to.X-from.X
+	if wrapsY(m) && abs(dy) > 1 {
+		dy = -dy
+	}
+
+	if wrapsX(m) && abs(dx) > 1 {
+		dx = -dx
+	}
+
+	switch {
+	case dy < 0:
+		return dirUp
+	case dy > 0:
+		return dirDown
+	case dx < 0:
+		return dirLeft
+	case dx > 0:
+		return dirRight
+	default:
+		return dirNone
+	}
+}
+
+// turnsBetween counts the 90° turns needed to go from heading a to heading b.
+func turnsBetween(a dir, b dir) int32 {
+	if a == dirNone || b == dirNone || a == b {
+		return 0
+	}
+
+	vertical := func(d dir) bool { return d == dirUp || d == dirDown }
+	if vertical(a) == vertical(b) {
+		return 2 // turning back
+	}
+
+	return 1
+}
+
+// turnsEnabled reports whether searches on the map have to track the heading.
+func turnsEnabled(m *model.GameMap) bool {
+	return m.TurnCost > 0 || m.MaxTurns > 0
+}
+
+type turnState struct {
+	node    model.Node
+	heading dir
+	turns   int32 // only tracked when the map limits turns
+}
+
+type turnItem struct {
+	state  turnState
+	g, f   int32
+	parent int32 // index in the visited arena, -1 for the start
+}
+
+type turnQueue []turnItem
+
+func (q turnQueue) Len() int           { return len(q) }
+func (q turnQueue) Less(i, j int) bool { return q[i].f < q[j].f }
+func (q turnQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *turnQueue) Push(x any) { *q = append(*q, x.(turnItem)) }
+
+func (q *turnQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
+
+const turnNodeSize = unsafe.Sizeof(turnItem{}) + unsafe.Sizeof(turnState{}) + 4
+
+// headingSearch is a best-first search over (cell, heading) states, so turns can be priced
+// and limited. With useHeuristic set it is A*, otherwise Dijkstra. allow works as in dijkstraSearch.
+// The turns of the start state count towards the turn limit.
+func headingSearch(
+	m *model.GameMap,
+	startState turnState,
+	target model.Node,
+	bgt *budget,
+	allow func(from, to model.Node) bool,
+	useHeuristic bool,
+) ([]*model.Node, int32, StopReason) {
+	h := func(n model.Node) int32 {
+		if !useHeuristic {
+			return 0
+		}
+
+		return bgt.opts.estimate(m, n, target)
+	}
+
+	costs := map[turnState]int32{}
+	closed := map[turnState]bool{}
+	var visited []turnItem
+
+	costs[startState] = 0
+	pq := &turnQueue{{state: startState, f: h(startState.node), parent: -1}}
+	bgt.generate(1)
+
+	for pq.Len() > 0 {
+		bgt.open(pq.Len())
+
+		current := heap.Pop(pq).(turnItem)
+		if closed[current.state] {
+			continue
+		}
+
+		if reason, ok := bgt.expand(); !ok {
+			return nil, 0, reason
+		}
+
+		closed[current.state] = true
+		visited = append(visited, current)
+		idx := int32(len(visited) - 1)
+
+		if current.state.node == target {
+			return turnPath(visited, idx), current.g, ReasonFound
+		}
+
+		for _, e := range edges(m, current.state.node) {
+			n := e.to
+			if allow != nil && !allow(current.state.node, n) {
+				continue
+			}
+
+			// portals keep the heading
+			d, turns := current.state.heading, int32(0)
+			if !e.portal {
+				d = moveDir(m, current.state.node, n)
+				turns = turnsBetween(current.state.heading, d)
+			}
+
+			next := turnState{node: n, heading: d}
+			if m.MaxTurns > 0 {
+				next.turns = current.state.turns + turns
+				if next.turns > m.MaxTurns {
+					continue
+				}
+			}
+
+			g := current.g + e.cost + turns*m.TurnCost
+			if known, ok := costs[next]; ok && known <= g {
+				continue
+			}
+
+			costs[next] = g
+			heap.Push(pq, turnItem{state: next, g: g, f: g + h(n), parent: idx})
+			bgt.generate(1)
+		}
+	}
+
+	return nil, 0, ReasonNoPath
+}
+
+// pathWalk follows a path cell by cell with the moves the searches use, keeping the cheapest
+// cost of every state the path can be in. A portal running parallel to a step gives two.
+type pathWalk struct {
+	m        *model.GameMap
+	maxTurns int32 // 0 - no limit
+	states   map[turnState]int32
+	edges    []edge
+}
+
+func newPathWalk(m *model.GameMap, from turnState, maxTurns int32) *pathWalk {
+	return &pathWalk{m: m, maxTurns: maxTurns, states: map[turnState]int32{from: 0}}
+}
+
+// step moves on to the next cell of the path. It returns false when no move reaches it
+// within the turn limit.
+func (w *pathWalk) step(to model.Node) bool {
+	next := make(map[turnState]int32, len(w.states))
+
+	for s, cost := range w.states {
+		w.edges = appendEdges(w.edges[:0], w.m, s.node)
+
+		for _, e := range w.edges {
+			if e.to != to {
+				continue
+			}
+
+			// portals keep the heading
+			ns, g := turnState{node: to, heading: s.heading, turns: s.turns}, cost+e.cost
+			if !e.portal {
+				d := moveDir(w.m, s.node, to)
+				turns := turnsBetween(s.heading, d)
+
+				ns.heading, g = d, g+turns*w.m.TurnCost
+				if w.maxTurns > 0 {
+					ns.turns += turns
+					if ns.turns > w.maxTurns {
+						continue
+					}
+				}
+			}
+
+			if known, ok := next[ns]; !ok || g < known {
+				next[ns] = g
+			}
+		}
+	}
+
+	if len(next) == 0 {
+		return false
+	}
+
+	w.states = next
+
+	return true
+}
+
+// best returns the cheapest state reached so far, the one with fewer turns on a tie.
+func (w *pathWalk) best() (int32, turnState) {
+	var best turnState
+	bestCost := int32(-1)
+
+	for s, cost := range w.states {
+		better := bestCost < 0 || cost < bestCost ||
+			cost == bestCost && (s.turns < best.turns || s.turns == best.turns && s.heading < best.heading)
+		if better {
+			best, bestCost = s, cost
+		}
+	}
+
+	return bestCost, best
+}
+
+// walkPath returns the cost of the cheapest moves along the whole path from the given state,
+// and the state it ends in. ok is false when the moves can't follow the path.
+func walkPath(m *model.GameMap, from turnState, path []*model.Node, maxTurns int32) (int32, turnState, bool) {
+	w := newPathWalk(m, from, maxTurns)
+
+	for _, n := range path[1:] {
+		if !w.step(*n) {
+			return 0, from, false
+		}
+	}
+
+	cost, end := w.best()
+
+	return cost, end, true
+}
+
+func turnPath(visited []turnItem, idx int32) []*model.Node {
+	var path []*model.Node
+	for ; idx >= 0; idx = visited[idx].parent {
+		n := visited[idx].state.node
+		path = append(path, &n)
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
@@ -0,0 +1,97 @@
+package algorithms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// turnFinders returns the searchers that follow turn rules.
+func turnFinders() map[string]PathFinder {
+	return map[string]PathFinder{"a-star": NewAstar(false), "dijkstra": &Dijkstra{}, "yen": kShortest{}}
+}
+
+// countTurns counts the 90° changes of direction along the path, starting with the heading.
+func countTurns(m *model.GameMap, heading model.Direction, path []*model.Node) int32 {
+	d := toDir(heading)
+
+	var turns int32
+	for i := 1; i < len(path); i++ {
+		next := moveDir(m, *path[i-1], *path[i])
+		if d != dirNone && next != d {
+			turns += turnsBetween(d, next)
+		}
+
+		d = next
+	}
+
+	return turns
+}
+
+func TestTurnCostPrefersStraightRuns(t *testing.T) {
+	m := openMap(t, 4, 4)
+	m.TurnCost = 10
+
+	for _, heading := range []model.Direction{model.DirectionNone, model.DirectionRight, model.DirectionDown} {
+		p := &model.Player{Target: model.Node{Y: 3, X: 3}, Heading: heading}
+
+		for name, f := range turnFinders() {
+			res := f.Find(context.Background(), *m, p, Options{})
+			if res.Reason != ReasonFound {
+				t.Fatalf("%s heading %q: reason = %s, want found", name, heading, res.Reason)
+			}
+
+			// six steps and the one turn between the two straight runs
+			if res.Stats.Cost != 16 || countTurns(m, heading, res.Path) != 1 {
+				t.Errorf("%s heading %q: cost %d along %v, want 16 with one turn", name, heading, res.Stats.Cost, nodes(res.Path))
+			}
+		}
+	}
+
+	// starting upwards the player has to turn right and then down
+	p := &model.Player{Target: model.Node{Y: 3, X: 3}, Heading: model.DirectionUp}
+	for name, f := range turnFinders() {
+		if res := f.Find(context.Background(), *m, p, Options{}); res.Stats.Cost != 26 {
+			t.Errorf("%s heading up: cost %d along %v, want 26", name, res.Stats.Cost, nodes(res.Path))
+		}
+	}
+}
+
+func TestMaxTurns(t *testing.T) {
+	// 0 0 1
+	// 1 0 0
+	m := &model.GameMap{Width: 3, Height: 2, Grid: [][]int32{{0, 0, 1}, {1, 0, 0}}}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		maxTurns int32
+		heading  model.Direction
+		want     StopReason
+	}{
+		{1, model.DirectionRight, ReasonNoPath},
+		{2, model.DirectionRight, ReasonFound},
+		{2, model.DirectionNone, ReasonFound},
+		{2, model.DirectionDown, ReasonNoPath}, // the first step already turns
+		{3, model.DirectionDown, ReasonFound},
+	}
+
+	for _, c := range cases {
+		m.MaxTurns = c.maxTurns
+		p := &model.Player{Target: model.Node{Y: 1, X: 2}, Heading: c.heading}
+
+		for name, f := range turnFinders() {
+			res := f.Find(context.Background(), *m, p, Options{})
+			if res.Reason != c.want {
+				t.Errorf("%s, max %d turns heading %q: reason = %s, want %s", name, c.maxTurns, c.heading, res.Reason, c.want)
+				continue
+			}
+
+			if res.Reason == ReasonFound && (res.Stats.Cost != 3 || countTurns(m, c.heading, res.Path) > c.maxTurns) {
+				t.Errorf("%s, max %d turns heading %q: cost %d along %v", name, c.maxTurns, c.heading, res.Stats.Cost, nodes(res.Path))
+			}
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package algorithms
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// visibility graphs compare floats built from the same input coordinates, eps only absorbs
+// rounding in the intersection math
+const eps = 1e-9
+
+// PrepareObstacles validates the obstacles and builds the visibility graph between their corners.
+// It must be called once per map before searching.
+func PrepareObstacles(om *model.ObstacleMap) error {
+	om.Vertices = nil
+
+	for i, o := range om.Obstacles {
+		if len(o) < 3 {
+			return fmt.Errorf("obstacle %d has less than 3 vertices", i)
+		}
+
+		om.Vertices = append(om.Vertices, o...)
+	}
+
+	om.Visible = make([][]int32, len(om.Vertices))
+
+	for i := range om.Vertices {
+		for j := i + 1; j < len(om.Vertices); j++ {
+			if visible(om, om.Vertices[i], om.Vertices[j]) {
+				om.Visible[i] = append(om.Visible[i], int32(j))
+				om.Visible[j] = append(om.Visible[j], int32(i))
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindAroundObstacles links the start and the target into the visibility graph of a prepared
+// map and searches it with A*.
+func FindAroundObstacles(ctx context.Context, om *model.ObstacleMap, p *model.NavPlayer, o Options) *NavResult {
+	bgt := newBudget(ctx, o, navNodeSize)
+	stop := func(r StopReason) *NavResult {
+		return &NavResult{Reason: r, Stats: bgt.stats(0)}
+	}
+
+	if blocked(om, p.Start) || blocked(om, p.Target) {
+		return stop(ReasonNoPath)
+	}
+
+	if visible(om, p.Start, p.Target) {
+		path := []model.Point{p.Start, p.Target}
+		length := dist(p.Start, p.Target)
+
+		return &NavResult{Path: path, Length: length, Reason: ReasonFound, Stats: bgt.stats(int32(math.Round(length)))}
+	}
+
+	// the start and the target get the two ids after the obstacle corners
+	start, goal := int32(len(om.Vertices)), int32(len(om.Vertices)+1)
+	point := func(v int32) model.Point {
+		switch v {
+		case start:
+			return p.Start
+		case goal:
+			return p.Target
+		default:
+			return om.Vertices[v]
+		}
+	}
+
+	fromStart := visibleFrom(om, p.Start)
+	toGoal := make(map[int32]bool)
+	for _, v := range visibleFrom(om, p.Target) {
+		toGoal[v] = true
+	}
+
+	neighbours := func(v int32) []int32 {
+		if v == start {
+			return fromStart
+		}
+
+		if toGoal[v] {
+			return append(slices.Clip(om.Visible[v]), goal)
+		}
+
+		return om.Visible[v]
+	}
+
+	costs := map[int32]float64{start: 0}
+	parents := map[int32]int32{start: -1}
+	closed := map[int32]bool{}
+	pq := &navQueue{{poly: start, f: dist(p.Start, p.Target)}}
+	bgt.generate(1)
+
+	for pq.Len() > 0 {
+		bgt.open(pq.Len())
+
+		current := heap.Pop(pq).(navItem)
+		if closed[current.poly] {
+			continue
+		}
+
+		if reason, ok := bgt.expand(); !ok {
+			return stop(reason)
+		}
+
+		closed[current.poly] = true
+
+		if current.poly == goal {
+			var path []model.Point
+			for v := goal; v >= 0; v = parents[v] {
+				path = append(path, point(v))
+			}
+
+			slices.Reverse(path)
+
+			return &NavResult{Path: path, Length: current.g, Reason: ReasonFound, Stats: bgt.stats(int32(math.Round(current.g)))}
+		}
+
+		for _, n := range neighbours(current.poly) {
+			if closed[n] {
+				continue
+			}
+
+			g := current.g + dist(point(current.poly), point(n))
+			if known, ok := costs[n]; ok && known <= g {
+				continue
+			}
+
+			costs[n] = g
+			parents[n] = current.poly
+			heap.Push(pq, navItem{poly: n, g: g, f: g + dist(point(n), p.Target)})
+			bgt.generate(1)
+		}
+	}
+
+	return stop(ReasonNoPath)
+}
+
+// visibleFrom returns the obstacle corners seen from the point.
+func visibleFrom(om *model.ObstacleMap, pt model.Point) []int32 {
+	var res []int32
+	for i, v := range om.Vertices {
+		if visible(om, pt, v) {
+			res = append(res, int32(i))
+		}
+	}
+
+	return res
+}
+
+// visible reports whether the segment a-b stays out of every obstacle. It may touch corners and
+// run along edges, but must not cross an edge or pass through the inside of an obstacle.
+func visible(om *model.ObstacleMap, a model.Point, b model.Point) bool {
+	// where the segment touches obstacle corners or edges, between these points it is
+	// either completely inside an obstacle or completely outside
+	cuts := []float64{0, 1}
+
+	for _, o := range om.Obstacles {
+		for k := range o {
+			c, d := o[k], o[(k+1)%len(o)]
+
+			if properCross(a, b, c, d) {
+				return false
+			}
+
+			for _, pt := range []model.Point{c, d} {
+				if t, ok := onSegment(a, b, pt); ok {
+					cuts = append(cuts, t)
+				}
+			}
+		}
+	}
+
+	slices.Sort(cuts)
+
+	for i := 1; i < len(cuts); i++ {
+		if cuts[i]-cuts[i-1] < eps {
+			continue
+		}
+
+		t := (cuts[i] + cuts[i-1]) / 2
+		if blocked(om, model.Point{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// properCross reports whether the segments a-b and c-d cross at a single point inside both.
+func properCross(a, b, c, d model.Point) bool {
+	d1, d2 := cross(a, b, c), cross(a, b, d)
+	d3, d4 := cross(c, d, a), cross(c, d, b)
+
+	return ((d1 > eps && d2 < -eps) || (d1 < -eps && d2 > eps)) &&
+		((d3 > eps && d4 < -eps) || (d3 < -eps && d4 > eps))
+}
+
+// onSegment returns where pt lies on the segment a-b, as a share of its length.
+func onSegment(a, b, pt model.Point) (float64, bool) {
+	l := dist(a, b)
+	if l < eps || math.Abs(cross(a, b, pt))/l > eps {
+		return 0, false
+	}
+
+	t := ((pt.X-a.X)*(b.X-a.X) + (pt.Y-a.Y)*(b.Y-a.Y)) / (l * l)
+
+	return t, t > 0 && t < 1
+}
+
+// blocked reports whether the point lies strictly inside an obstacle.
+func blocked(om *model.ObstacleMap, pt model.Point) bool {
+	for _, o := range om.Obstacles {
+		if insidePolygon(o, pt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// insidePolygon is an even-odd test, points on the outline are outside.
+func insidePolygon(poly []model.Point, pt model.Point) bool {
+	inside := false
+
+	for k := range poly {
+		a, b := poly[k], poly[(k+1)%len(poly)]
+		if _, on := onSegment(a, b, pt); on || dist(a, pt) < eps {
+			return false
+		}
+
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
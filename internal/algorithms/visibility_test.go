@@ -0,0 +1,158 @@
+package algorithms
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// square returns the obstacle covering the square from (x, y) to (x+size, y+size).
+func square(x, y, size float64) []model.Point {
+	return []model.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+func obstacleMap(t *testing.T, obstacles ...[]model.Point) *model.ObstacleMap {
+	t.Helper()
+
+	om := &model.ObstacleMap{Obstacles: obstacles}
+	if err := PrepareObstacles(om); err != nil {
+		t.Fatal(err)
+	}
+
+	return om
+}
+
+func TestVisibleSegments(t *testing.T) {
+	// a square, and an L opening to the top right
+	om := obstacleMap(t, square(2, 2, 2), []model.Point{{X: 6, Y: 0}, {X: 7, Y: 0}, {X: 7, Y: 3}, {X: 9, Y: 3}, {X: 9, Y: 4}, {X: 6, Y: 4}})
+
+	cases := []struct {
+		name    string
+		a, b    model.Point
+		visible bool
+	}{
+		{"ends at a corner", model.Point{}, model.Point{X: 2, Y: 2}, true},
+		{"touches a corner", model.Point{X: 0, Y: 4}, model.Point{X: 4, Y: 0}, true},
+		{"along an edge", model.Point{X: 2, Y: 0}, model.Point{X: 2, Y: 6}, true},
+		{"along an edge, corner to corner", model.Point{X: 2, Y: 2}, model.Point{X: 4, Y: 2}, true},
+		{"crosses", model.Point{X: 0, Y: 3}, model.Point{X: 5, Y: 3}, false},
+		{"diagonal of the square", model.Point{X: 2, Y: 2}, model.Point{X: 4, Y: 4}, false},
+		{"ends inside", model.Point{X: 0, Y: 3}, model.Point{X: 3, Y: 3}, false},
+		{"along an edge into the inside", model.Point{X: 0, Y: 2}, model.Point{X: 3, Y: 3}, false},
+		{"across the opening of the L", model.Point{X: 7, Y: 0}, model.Point{X: 9, Y: 3}, true},
+		{"across the L", model.Point{X: 6, Y: 0}, model.Point{X: 9, Y: 3}, false},
+	}
+
+	for _, c := range cases {
+		if got := visible(om, c.a, c.b); got != c.visible {
+			t.Errorf("%s: visible %v, want %v", c.name, got, c.visible)
+		}
+
+		if got := visible(om, c.b, c.a); got != c.visible {
+			t.Errorf("%s, reversed: visible %v, want %v", c.name, got, c.visible)
+		}
+	}
+}
+
+func TestFindAroundObstacles(t *testing.T) {
+	om := obstacleMap(t, square(2, 2, 2))
+
+	cases := []struct {
+		name   string
+		p      model.NavPlayer
+		reason StopReason
+		points []model.Point
+		length float64
+	}{
+		{
+			// the top side of the square is closer to the start and the target
+			name:   "around the square",
+			p:      model.NavPlayer{Start: model.Point{X: 0, Y: 2.5}, Target: model.Point{X: 6, Y: 2.5}},
+			reason: ReasonFound,
+			points: []model.Point{{X: 0, Y: 2.5}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 6, Y: 2.5}},
+			length: 2 + 2*math.Hypot(2, 0.5),
+		},
+		{
+			name:   "along an edge",
+			p:      model.NavPlayer{Start: model.Point{X: 2, Y: 0}, Target: model.Point{X: 2, Y: 6}},
+			reason: ReasonFound,
+			points: []model.Point{{X: 2, Y: 0}, {X: 2, Y: 6}},
+			length: 6,
+		},
+		{
+			name:   "touching a corner",
+			p:      model.NavPlayer{Start: model.Point{X: 0, Y: 4}, Target: model.Point{X: 4, Y: 0}},
+			reason: ReasonFound,
+			points: []model.Point{{X: 0, Y: 4}, {X: 4, Y: 0}},
+			length: math.Hypot(4, 4),
+		},
+		{
+			name:   "start on the outline",
+			p:      model.NavPlayer{Start: model.Point{X: 3, Y: 4}, Target: model.Point{X: 3.5, Y: 0}},
+			reason: ReasonFound,
+			points: []model.Point{{X: 3, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 2}, {X: 3.5, Y: 0}},
+			length: 1 + 2 + math.Hypot(0.5, 2),
+		},
+		{
+			name:   "start inside",
+			p:      model.NavPlayer{Start: model.Point{X: 3, Y: 3}, Target: model.Point{X: 6, Y: 3}},
+			reason: ReasonNoPath,
+		},
+		{
+			name:   "target inside",
+			p:      model.NavPlayer{Start: model.Point{X: 0, Y: 0}, Target: model.Point{X: 3, Y: 3}},
+			reason: ReasonNoPath,
+		},
+	}
+
+	for _, c := range cases {
+		res := FindAroundObstacles(context.Background(), om, &c.p, Options{})
+		if res.Reason != c.reason {
+			t.Errorf("%s: got %s, want %s", c.name, res.Reason, c.reason)
+			continue
+		}
+
+		if !slices.Equal(res.Path, c.points) {
+			t.Errorf("%s: points %v, want %v", c.name, res.Path, c.points)
+		}
+
+		if math.Abs(res.Length-c.length) > 1e-9 {
+			t.Errorf("%s: length %f, want %f", c.name, res.Length, c.length)
+		}
+	}
+}
+
+// A target walled in by overlapping bars can't be reached, through the corners the bars
+// share or otherwise.
+func TestFindAroundObstaclesNoPath(t *testing.T) {
+	bar := func(x0, y0, x1, y1 float64) []model.Point {
+		return []model.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+	}
+
+	om := obstacleMap(t, bar(0, 0, 10, 1), bar(9, 0, 10, 10), bar(0, 9, 10, 10), bar(0, 0, 1, 10))
+
+	for _, p := range []model.NavPlayer{
+		{Start: model.Point{X: -5, Y: 5}, Target: model.Point{X: 5, Y: 5}},
+		{Start: model.Point{X: 5, Y: 5}, Target: model.Point{X: 15, Y: 15}},
+	} {
+		if res := FindAroundObstacles(context.Background(), om, &p, Options{}); res.Reason != ReasonNoPath || res.Path != nil {
+			t.Errorf("%v to %v: got %s with %v, want no_path", p.Start, p.Target, res.Reason, res.Path)
+		}
+	}
+
+	// inside the frame players move freely
+	p := model.NavPlayer{Start: model.Point{X: 2, Y: 2}, Target: model.Point{X: 8, Y: 8}}
+	if res := FindAroundObstacles(context.Background(), om, &p, Options{}); res.Reason != ReasonFound {
+		t.Errorf("inside the frame: got %s, want found", res.Reason)
+	}
+}
+
+func TestPrepareObstaclesRejectsSegments(t *testing.T) {
+	om := &model.ObstacleMap{Obstacles: [][]model.Point{square(0, 0, 1), {{X: 2, Y: 2}, {X: 3, Y: 3}}}}
+	if err := PrepareObstacles(om); err == nil {
+		t.Error("an obstacle with 2 vertices: no error")
+	}
+}
@@ -0,0 +1,94 @@
+package algorithms
+
+import "github.com/unomns/findpath/internal/model"
+
+// voxel maps treat levels as the third axis of one grid. Every move costs a single step, so a
+// diagonal move is as cheap as a straight one, like a king in chess.
+
+func isVoxel(m *model.GameMap) bool {
+	return m.Connectivity != model.ConnectivityLevels
+}
+
+// maxAxes returns how many coordinates a single move may change.
+func maxAxes(m *model.GameMap) int {
+	switch m.Connectivity {
+	case model.Connectivity18:
+		return 2
+	case model.Connectivity26:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// voxelEdges appends the walkable voxels around n, then the portals starting at n. A diagonal
+// move must not cut a corner: every voxel it passes by has to be walkable too.
+func voxelEdges(res []edge, m *model.GameMap, n model.Node) []edge {
+	for dz := int32(-1); dz <= 1; dz++ {
+		for dy := int32(-1); dy <= 1; dy++ {
+			for dx := int32(-1); dx <= 1; dx++ {
+				axes := int(abs(dz) + abs(dy) + abs(dx))
+				if axes == 0 || axes > maxAxes(m) {
+					continue
+				}
+
+				to, ok := voxelOffset(m, n, dz, dy, dx)
+				if !ok || !walkable(m, to) || (axes > 1 && !clearCorners(m, n, dz, dy, dx)) {
+					continue
+				}
+
+				res = append(res, edge{to: to, cost: enterCost(m, to)})
+			}
+		}
+	}
+
+	for _, p := range m.PortalsFrom[n] {
+		res = append(res, edge{to: p.To, cost: p.Cost, portal: true})
+	}
+
+	return res
+}
+
+// voxelOffset moves n by the offset, wrapping x and y when the map wraps. ok is false when the
+// result is off the map or n itself.
+func voxelOffset(m *model.GameMap, n model.Node, dz, dy, dx int32) (to model.Node, ok bool) {
+	to = model.Node{Z: n.Z + dz, Y: n.Y + dy, X: n.X + dx}
+
+	if wrapsY(m) {
+		to.Y = (to.Y + m.Height) % m.Height
+	}
+
+	if wrapsX(m) {
+		to.X = (to.X + m.Width) % m.Width
+	}
+
+	return to, to != n && inBounds(m, to)
+}
+
+// clearCorners checks the voxels reached by every partial offset of a diagonal move.
+func clearCorners(m *model.GameMap, n model.Node, dz, dy, dx int32) bool {
+	for mask := 1; mask < 7; mask++ {
+		pz, py, px := dz*int32(mask&1), dy*int32(mask>>1&1), dx*int32(mask>>2&1)
+		if (pz == dz && py == dy && px == dx) || (pz == 0 && py == 0 && px == 0) {
+			continue
+		}
+
+		if c, ok := voxelOffset(m, n, pz, py, px); ok && !walkable(m, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// voxelDistance is the least number of moves between two voxels with the map connectivity.
+func voxelDistance(m *model.GameMap, dz, dy, dx int32) int32 {
+	switch m.Connectivity {
+	case model.Connectivity18:
+		return max(dz, dy, dx, (dz+dy+dx+1)/2)
+	case model.Connectivity26:
+		return max(dz, dy, dx)
+	default:
+		return dz + dy + dx
+	}
+}
@@ -0,0 +1,92 @@
+package algorithms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+func TestWrappedDistance(t *testing.T) {
+	m := openMap(t, 10, 6)
+
+	cases := []struct {
+		wrap   model.Wrap
+		target model.Node
+		want   int32
+	}{
+		{model.WrapNone, model.Node{X: 9}, 9},
+		{model.WrapHorizontal, model.Node{X: 9}, 1},
+		{model.WrapHorizontal, model.Node{X: 5}, 5},
+		{model.WrapHorizontal, model.Node{Y: 5}, 5},
+		{model.WrapVertical, model.Node{Y: 5}, 1},
+		{model.WrapVertical, model.Node{X: 9}, 9},
+		{model.WrapBoth, model.Node{Y: 5, X: 9}, 2},
+		{model.WrapBoth, model.Node{Y: 3, X: 7}, 6},
+	}
+
+	for _, c := range cases {
+		m.Wrap = c.wrap
+
+		if got := distance(m, model.Node{}, c.target); got != c.want {
+			t.Errorf("%q: distance to %v = %d, want %d", c.wrap, c.target, got, c.want)
+		}
+
+		if got := distance(m, c.target, model.Node{}); got != c.want {
+			t.Errorf("%q: distance from %v = %d, want %d", c.wrap, c.target, got, c.want)
+		}
+	}
+}
+
+func TestSearchesCrossWrappedEdges(t *testing.T) {
+	// 0 0 1 0 0
+	// 0 0 1 0 0
+	// 1 1 1 1 1
+	// 0 0 1 0 0
+	grid := [][]int32{{0, 0, 1, 0, 0}, {0, 0, 1, 0, 0}, {1, 1, 1, 1, 1}, {0, 0, 1, 0, 0}}
+
+	cases := []struct {
+		wrap model.Wrap
+		cost int32 // from the top left to the bottom right corner, 0 - no path
+	}{
+		{model.WrapNone, 0},
+		{model.WrapHorizontal, 0},
+		{model.WrapVertical, 0},
+		{model.WrapBoth, 2},
+	}
+
+	for _, c := range cases {
+		m := &model.GameMap{Width: 5, Height: 4, Grid: grid, Wrap: c.wrap}
+		if err := Prepare(m); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, f := range finders() {
+			res := f.Find(context.Background(), *m, &model.Player{Target: model.Node{Y: 3, X: 4}}, Options{})
+			if c.cost == 0 {
+				if res.Reason != ReasonNoPath {
+					t.Errorf("%s, %q: reason = %s, want no_path", name, c.wrap, res.Reason)
+				}
+
+				continue
+			}
+
+			if res.Reason != ReasonFound || res.Stats.Cost != c.cost || len(res.Path) != int(c.cost)+1 {
+				t.Errorf("%s, %q: %s with cost %d along %v, want cost %d", name, c.wrap, res.Reason, res.Stats.Cost, nodes(res.Path), c.cost)
+			}
+		}
+	}
+
+	// the wall in the middle column is bypassed through the left edge
+	m := &model.GameMap{Width: 5, Height: 4, Grid: grid, Wrap: model.WrapHorizontal}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, f := range finders() {
+		res := f.Find(context.Background(), *m, &model.Player{Target: model.Node{Y: 1, X: 4}}, Options{})
+		if res.Reason != ReasonFound || res.Stats.Cost != 2 {
+			t.Errorf("%s, horizontal: %s with cost %d along %v, want cost 2", name, res.Reason, res.Stats.Cost, nodes(res.Path))
+		}
+	}
+}
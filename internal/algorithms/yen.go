@@ -0,0 +1,189 @@
+package algorithms
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Route is one of the ranked paths returned by KShortestPaths.
+type Route struct {
+	Path []*model.Node
+	Cost int32
+}
+
+// how many more spur rounds may be spent per requested route when some are rejected as too similar
+const yenDiversityFactor = 8
+
+// KShortestPaths runs Yen's algorithm on top of Dijkstra and returns up to k loopless paths
+// ranked by cost. When maxOverlap is in (0, 1) a path is dropped if more than that share
+// of its cells is also used by an already accepted route.
+// The limits in o apply to the whole run, not to each spur search.
+func KShortestPaths(ctx context.Context, m model.GameMap, p *model.Player, o Options, k int, maxOverlap float64) *Result {
+	bgt := newBudget(ctx, o, dijkstraNodeSize)
+
+	if k < 1 || !walkable(&m, p.Start) {
+		return stopped(ReasonNoPath, bgt.stats(0))
+	}
+
+	start := turnState{node: p.Start, heading: toDir(p.Heading)}
+
+	first, cost, reason := dijkstraSearch(&m, start, p.Target, bgt, nil)
+	if reason != ReasonFound {
+		return stopped(reason, bgt.stats(0))
+	}
+
+	diverse := maxOverlap > 0 && maxOverlap < 1
+	rounds := k
+	if diverse {
+		rounds = k * yenDiversityFactor
+	}
+
+	// explored holds every path taken from the candidates, accepted or not, since
+	// spurs are generated from all of them
+	explored := []*Route{{Path: first, Cost: cost}}
+	accepted := []*Route{explored[0]}
+	seen := map[string]bool{routeKey(first): true}
+	var candidates []*Route
+
+	for len(accepted) < k && len(explored) < rounds {
+		prev := explored[len(explored)-1].Path
+
+		// the spur searches go on in the state the root ends in, so the heading and the
+		// turns already taken carry across portals
+		root := newPathWalk(&m, start, m.MaxTurns)
+
+		for i := 0; i < len(prev)-1; i++ {
+			if i > 0 && !root.step(*prev[i]) {
+				break
+			}
+
+			_, spurState := root.best()
+
+			blockedEdges := make(map[[2]model.Node]bool)
+			for _, r := range explored {
+				if len(r.Path) > i+1 && samePrefix(r.Path, prev[:i+1]) {
+					blockedEdges[[2]model.Node{*r.Path[i], *r.Path[i+1]}] = true
+				}
+			}
+
+			blockedNodes := make(map[model.Node]bool, i)
+			for _, n := range prev[:i] {
+				blockedNodes[*n] = true
+			}
+
+			spurPath, _, reason := dijkstraSearch(&m, spurState, p.Target, bgt, func(from, to model.Node) bool {
+				return !blockedNodes[to] && !blockedEdges[[2]model.Node{from, to}]
+			})
+
+			if reason == ReasonCancelled || reason == ReasonBudgetExceeded {
+				// the accepted routes are still valid, there are just fewer of them
+				return routesResult(accepted, bgt)
+			}
+
+			if reason != ReasonFound {
+				continue
+			}
+
+			path := make([]*model.Node, 0, i+len(spurPath))
+			path = append(path, prev[:i]...)
+			path = append(path, spurPath...)
+
+			key := routeKey(path)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			// the cheapest moves along the joined path may differ from those of the root and
+			// the spur, e.g. when a portal runs parallel to a step
+			pathCost, _, ok := walkPath(&m, start, path, m.MaxTurns)
+			if !ok {
+				continue
+			}
+
+			candidates = append(candidates, &Route{Path: path, Cost: pathCost})
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for j, c := range candidates {
+			if c.Cost < candidates[best].Cost {
+				best = j
+			}
+		}
+
+		next := candidates[best]
+		candidates = append(candidates[:best], candidates[best+1:]...)
+		explored = append(explored, next)
+
+		if !diverse || maxOverlapWith(next, accepted) <= maxOverlap {
+			accepted = append(accepted, next)
+		}
+	}
+
+	return routesResult(accepted, bgt)
+}
+
+func routesResult(routes []*Route, bgt *budget) *Result {
+	// the spurs start from the cheapest state of their root only, so a later route can
+	// still come out cheaper than an earlier one
+	slices.SortStableFunc(routes, func(a, b *Route) int { return cmp.Compare(a.Cost, b.Cost) })
+
+	res := found(routes[0].Path, bgt.stats(routes[0].Cost))
+	res.Routes = routes
+
+	return res
+}
+
+func samePrefix(path []*model.Node, prefix []*model.Node) bool {
+	for i, n := range prefix {
+		if *path[i] != *n {
+			return false
+		}
+	}
+
+	return true
+}
+
+// maxOverlapWith returns the biggest share of r's cells used by any of the routes.
+func maxOverlapWith(r *Route, routes []*Route) float64 {
+	var res float64
+
+	for _, other := range routes {
+		cells := make(map[model.Node]bool, len(other.Path))
+		for _, n := range other.Path {
+			cells[*n] = true
+		}
+
+		shared := 0
+		for _, n := range r.Path {
+			if cells[*n] {
+				shared++
+			}
+		}
+
+		if o := float64(shared) / float64(len(r.Path)); o > res {
+			res = o
+		}
+	}
+
+	return res
+}
+
+func routeKey(path []*model.Node) string {
+	var sb strings.Builder
+	for _, n := range path {
+		fmt.Fprintf(&sb, "%d-%d-%d;", n.Z, n.Y, n.X)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package algorithms
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+func TestKShortestPathsRanksRoutes(t *testing.T) {
+	m := &model.GameMap{Width: 3, Height: 3, Grid: [][]int32{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}}}
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &model.Player{Target: model.Node{Y: 2, X: 2}}
+
+	res := KShortestPaths(context.Background(), *m, p, Options{}, 8, 0)
+	if res.Reason != ReasonFound {
+		t.Fatalf("reason = %s, want found", res.Reason)
+	}
+
+	// six shortest paths of 4 steps, the next ones take a detour of 2
+	want := []int32{4, 4, 4, 4, 4, 4, 6, 6}
+	if len(res.Routes) != len(want) {
+		t.Fatalf("got %d routes, want %d", len(res.Routes), len(want))
+	}
+
+	seen := map[string]bool{}
+	for i, r := range res.Routes {
+		if r.Cost != want[i] {
+			t.Errorf("route %d costs %d, want %d", i, r.Cost, want[i])
+		}
+
+		if seen[routeKey(r.Path)] {
+			t.Errorf("route %d is a duplicate: %v", i, nodes(r.Path))
+		}
+
+		seen[routeKey(r.Path)] = true
+	}
+
+	if res.Stats.Cost != 4 || len(res.Path) != 5 {
+		t.Errorf("result is not the best route: cost %d, %d cells", res.Stats.Cost, len(res.Path))
+	}
+}
+
+func TestKShortestPathsLimitsOverlap(t *testing.T) {
+	m := &model.GameMap{Width: 5, Height: 5, Grid: make([][]int32, 5)}
+	for y := range m.Grid {
+		m.Grid[y] = make([]int32, 5)
+	}
+
+	if err := Prepare(m); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &model.Player{Target: model.Node{Y: 4, X: 4}}
+
+	res := KShortestPaths(context.Background(), *m, p, Options{}, 3, 0.5)
+	if len(res.Routes) < 2 {
+		t.Fatalf("got %d routes, want at least 2", len(res.Routes))
+	}
+
+	for i, r := range res.Routes {
+		if o := maxOverlapWith(r, res.Routes[:i]); o > 0.5 {
+			t.Errorf("route %d shares %.2f of its cells with an earlier route, want at most 0.5", i, o)
+		}
+	}
+}
+
+// Route costs must be the cost of walking their cells, in order, on maps where the heading
+// carries across portals and connectors.
+func TestKShortestPathsCostsMatchPaths(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	headings := []model.Direction{model.DirectionNone, model.DirectionUp, model.DirectionDown, model.DirectionLeft, model.DirectionRight}
+
+	for i := range 400 {
+		m := randomMap(t, r, mapFeatures{levels: true, portals: i%2 == 0, exits: i%3 == 0, turns: true})
+		p := &model.Player{Start: randomWalkable(r, m), Target: randomWalkable(r, m), Heading: headings[r.Intn(len(headings))]}
+
+		res := KShortestPaths(context.Background(), *m, p, Options{}, 5, 0)
+		if res.Reason != ReasonFound {
+			continue
+		}
+
+		best := (&Dijkstra{}).Find(context.Background(), *m, p, Options{})
+		if res.Routes[0].Cost != best.Stats.Cost {
+			t.Fatalf("map %d: best route costs %d, Dijkstra found %d", i, res.Routes[0].Cost, best.Stats.Cost)
+		}
+
+		for j, route := range res.Routes {
+			if got := followCost(t, m, p, route.Path); got != route.Cost {
+				t.Fatalf("map %d route %d: cost %d, walking it costs %d: %v", i, j, route.Cost, got, nodes(route.Path))
+			}
+
+			if j > 0 && route.Cost < res.Routes[j-1].Cost {
+				t.Fatalf("map %d: route %d costs %d, less than the %d of the route before", i, j, route.Cost, res.Routes[j-1].Cost)
+			}
+		}
+	}
+}
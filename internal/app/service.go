@@ -1,24 +1,27 @@
 package app
 
 import (
+	"context"
+
 	"github.com/unomns/findpath/internal/algorithms"
 	"github.com/unomns/findpath/internal/model"
 )
 
 type pathFindingService struct {
 	algo algorithms.PathFinder
+	opts algorithms.Options
 }
 
-func NewPathFindingService(algo algorithms.PathFinder) *pathFindingService {
-	return &pathFindingService{algo: algo}
+func NewPathFindingService(algo algorithms.PathFinder, opts algorithms.Options) *pathFindingService {
+	return &pathFindingService{algo: algo, opts: opts}
 }
 
-func (s *pathFindingService) FindPath(m model.GameMap, p *model.Player) []*model.Node {
-	for y, arr := range m.Grid {
-		for x := range arr {
-			m.Map = append(m.Map, model.Node{Y: int32(y), X: int32(x)})
-		}
-	}
+func (s *pathFindingService) FindPath(ctx context.Context, m model.GameMap, p *model.Player) *algorithms.Result {
+	return s.algo.Find(ctx, m, p, s.opts)
+}
 
-	return s.algo.Find(m, p)
+// FindRoutes returns up to k ranked alternative paths. It always searches with Yen's
+// algorithm, whatever algorithm the service was created with.
+func (s *pathFindingService) FindRoutes(ctx context.Context, m model.GameMap, p *model.Player, k int, maxOverlap float64) *algorithms.Result {
+	return algorithms.KShortestPaths(ctx, m, p, s.opts, k, maxOverlap)
 }
@@ -9,6 +9,8 @@ func NewPathFinder(algo string, debugMode bool) (algorithms.PathFinder, error) {
 	switch algo {
 	case "a", "a-star":
 		return algorithms.NewAstar(debugMode), nil
+	case "j", "jps":
+		return algorithms.NewJumpPoint(debugMode), nil
 	case "b", "bfs":
 		return &algorithms.Bfs{}, nil
 	case "d", "dijkstra":
@@ -3,35 +3,288 @@ package app_grpc
 import (
 	"github.com/unomns/findpath/pkg/findpath"
 	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ToGRPCPaths(paths []*findpath.Path) []*findpathv1.Path {
 	res := make([]*findpathv1.Path, len(paths))
 
 	for i, p := range paths {
-		fp := &findpathv1.Path{Found: p.Found, PlayerId: p.PlayerID}
-		if p.Found {
-			fp.Steps = make([]*findpathv1.Node, len(p.Steps))
-			for k, s := range p.Steps {
-				fp.Steps[k] = &findpathv1.Node{Y: s.Y, X: s.X}
-			}
-		}
+		res[i] = ToGRPCPath(p)
+	}
+
+	return res
+}
+
+var statuses = map[findpath.Status]findpathv1.Path_Status{
+	findpath.StatusOK:             findpathv1.Path_OK,
+	findpath.StatusNoPath:         findpathv1.Path_NO_PATH,
+	findpath.StatusInvalidStart:   findpathv1.Path_INVALID_START,
+	findpath.StatusInvalidTarget:  findpathv1.Path_INVALID_TARGET,
+	findpath.StatusCancelled:      findpathv1.Path_CANCELLED,
+	findpath.StatusBudgetExceeded: findpathv1.Path_BUDGET_EXCEEDED,
+}
 
-		res[i] = fp
+func ToGRPCPath(p *findpath.Path) *findpathv1.Path {
+	fp := &findpathv1.Path{
+		Found:    p.Found,
+		PlayerId: p.PlayerID,
+		Stats:    toGRPCStats(p.Stats),
+		Status:   statuses[p.Status],
+		Message:  p.Message,
+		Metadata: p.Metadata,
+	}
+	if p.Found {
+		fp.Steps = toGRPCSteps(p.Steps)
+	}
+
+	for _, r := range p.Routes {
+		fp.Routes = append(fp.Routes, &findpathv1.Route{Steps: toGRPCSteps(r.Steps), Cost: r.Cost})
+	}
+
+	return fp
+}
+
+func toGRPCSteps(steps []*findpath.Node) []*findpathv1.Node {
+	res := make([]*findpathv1.Node, len(steps))
+	for k, s := range steps {
+		res[k] = &findpathv1.Node{Y: s.Y, X: s.X, Z: s.Z}
 	}
 
 	return res
 }
 
+var directions = map[findpathv1.Direction]findpath.Direction{
+	findpathv1.Direction_NONE:  findpath.DirectionNone,
+	findpathv1.Direction_UP:    findpath.DirectionUp,
+	findpathv1.Direction_DOWN:  findpath.DirectionDown,
+	findpathv1.Direction_LEFT:  findpath.DirectionLeft,
+	findpathv1.Direction_RIGHT: findpath.DirectionRight,
+}
+
+var wraps = map[findpathv1.Wrap]findpath.Wrap{
+	findpathv1.Wrap_WRAP_NONE:       findpath.WrapNone,
+	findpathv1.Wrap_WRAP_HORIZONTAL: findpath.WrapHorizontal,
+	findpathv1.Wrap_WRAP_VERTICAL:   findpath.WrapVertical,
+	findpathv1.Wrap_WRAP_BOTH:       findpath.WrapBoth,
+}
+
+var connectivities = map[findpathv1.Connectivity]findpath.Connectivity{
+	findpathv1.Connectivity_CONNECTIVITY_26: findpath.Connectivity26,
+	findpathv1.Connectivity_CONNECTIVITY_18: findpath.Connectivity18,
+	findpathv1.Connectivity_CONNECTIVITY_6:  findpath.Connectivity6,
+}
+
 func FromGRPCPlayers(players []*findpathv1.Player) []*findpath.Player {
 	res := make([]*findpath.Player, len(players))
 
 	for i, p := range players {
 		res[i] = &findpath.Player{
-			Start:  findpath.Node{Y: p.Start.Y, X: p.Start.X},
-			Target: findpath.Node{Y: p.Target.Y, X: p.Target.X},
+			ID:       p.Id,
+			Start:    fromGRPCNode(p.Start),
+			Target:   fromGRPCNode(p.Target),
+			Heading:  directions[p.Heading],
+			Metadata: p.Metadata,
+		}
+	}
+
+	return res
+}
+
+func toGRPCStats(s *findpath.Stats) *findpathv1.SearchStats {
+	if s == nil {
+		return nil
+	}
+
+	return &findpathv1.SearchStats{
+		Cost:           s.Cost,
+		NodesExpanded:  int64(s.NodesExpanded),
+		NodesGenerated: int64(s.NodesGenerated),
+		PeakOpen:       int64(s.PeakOpen),
+		Duration:       durationpb.New(s.Duration),
+	}
+}
+
+var falloffs = map[findpathv1.InfluenceSource_Falloff]findpath.Falloff{
+	findpathv1.InfluenceSource_LINEAR:    findpath.FalloffLinear,
+	findpathv1.InfluenceSource_CONSTANT:  findpath.FalloffConstant,
+	findpathv1.InfluenceSource_QUADRATIC: findpath.FalloffQuadratic,
+}
+
+func FromGRPCCostLayers(layers []*findpathv1.CostLayer) []*findpath.CostLayer {
+	res := make([]*findpath.CostLayer, len(layers))
+
+	for i, l := range layers {
+		res[i] = &findpath.CostLayer{Name: l.Name, Dense: l.Dense}
+
+		for _, c := range l.Cells {
+			res[i].Cells = append(res[i].Cells, &findpath.CellCost{Node: fromGRPCNode(c.Cell), Cost: c.Cost})
+		}
+
+		for _, s := range l.Sources {
+			res[i].Sources = append(res[i].Sources, &findpath.InfluenceSource{
+				Node:     fromGRPCNode(s.Cell),
+				Strength: s.Strength,
+				Radius:   s.Radius,
+				Falloff:  falloffs[s.Falloff],
+			})
+		}
+	}
+
+	return res
+}
+
+func fromGRPCNode(n *findpathv1.Node) findpath.Node {
+	return findpath.Node{Y: n.GetY(), X: n.GetX(), Z: n.GetZ()}
+}
+
+func FromGRPCPortals(portals []*findpathv1.Portal) []*findpath.Portal {
+	res := make([]*findpath.Portal, len(portals))
+	for i, p := range portals {
+		res[i] = &findpath.Portal{From: fromGRPCNode(p.From), To: fromGRPCNode(p.To), Cost: p.Cost}
+	}
+
+	return res
+}
+
+func FromGRPCExits(exits []*findpathv1.CellExits) []*findpath.CellExits {
+	res := make([]*findpath.CellExits, len(exits))
+	for i, e := range exits {
+		res[i] = &findpath.CellExits{Node: fromGRPCNode(e.Cell), Allow: make([]findpath.Direction, len(e.Allow))}
+		for k, d := range e.Allow {
+			res[i].Allow[k] = directions[d]
+		}
+	}
+
+	return res
+}
+
+func FromGRPCConnectors(connectors []*findpathv1.Connector) []*findpath.Connector {
+	res := make([]*findpath.Connector, len(connectors))
+	for i, c := range connectors {
+		res[i] = &findpath.Connector{Kind: c.Kind, Cost: c.Cost, Cells: make([]findpath.Node, len(c.Cells))}
+		for k, n := range c.Cells {
+			res[i].Cells[k] = fromGRPCNode(n)
 		}
 	}
 
 	return res
 }
+
+func FromGRPCNavMesh(vertices []*findpathv1.Point, polygons []*findpathv1.Polygon) (*findpath.NavMesh, error) {
+	vs := make([]findpath.Point, len(vertices))
+	for i, v := range vertices {
+		vs[i] = findpath.Point{X: v.X, Y: v.Y}
+	}
+
+	ps := make([][]int32, len(polygons))
+	for i, p := range polygons {
+		ps[i] = p.Vertices
+	}
+
+	return findpath.NewNavMesh(vs, ps)
+}
+
+func FromGRPCNavPlayers(players []*findpathv1.NavPlayer) []*findpath.NavPlayer {
+	res := make([]*findpath.NavPlayer, len(players))
+	for i, p := range players {
+		res[i] = &findpath.NavPlayer{
+			ID:       p.Id,
+			Start:    findpath.Point{X: p.Start.GetX(), Y: p.Start.GetY()},
+			Target:   findpath.Point{X: p.Target.GetX(), Y: p.Target.GetY()},
+			Metadata: p.Metadata,
+		}
+	}
+
+	return res
+}
+
+func ToGRPCNavPaths(paths []*findpath.NavPath) []*findpathv1.NavPath {
+	res := make([]*findpathv1.NavPath, len(paths))
+	for i, p := range paths {
+		res[i] = &findpathv1.NavPath{
+			PlayerId: p.PlayerID,
+			Found:    p.Found,
+			Length:   p.Length,
+			Stats:    toGRPCStats(p.Stats),
+			Metadata: p.Metadata,
+		}
+
+		for _, pt := range p.Points {
+			res[i].Points = append(res[i].Points, &findpathv1.Point{X: pt.X, Y: pt.Y})
+		}
+	}
+
+	return res
+}
+
+func FromGRPCPathRequest(req *findpathv1.PathRequest) *findpath.Grid {
+	return &findpath.Grid{
+		Width:        req.Width,
+		Height:       req.Height,
+		Depth:        req.Depth,
+		Cells:        req.Grid,
+		Connectors:   FromGRPCConnectors(req.Connectors),
+		CostLayers:   FromGRPCCostLayers(req.CostLayers),
+		LayerWeights: req.LayerWeights,
+		TurnCost:     req.TurnCost,
+		MaxTurns:     req.MaxTurns,
+		Wrap:         wraps[req.Wrap],
+		Portals:      FromGRPCPortals(req.Portals),
+		Exits:        FromGRPCExits(req.Exits),
+	}
+}
+
+func FromGRPCUploadMap(req *findpathv1.UploadMapRequest) *findpath.Grid {
+	g := &findpath.Grid{
+		Width:        req.Width,
+		Height:       req.Height,
+		Depth:        req.Depth,
+		Cells:        req.Grid,
+		Connectors:   FromGRPCConnectors(req.Connectors),
+		CostLayers:   FromGRPCCostLayers(req.CostLayers),
+		LayerWeights: req.LayerWeights,
+		TurnCost:     req.TurnCost,
+		MaxTurns:     req.MaxTurns,
+		Wrap:         wraps[req.Wrap],
+		Portals:      FromGRPCPortals(req.Portals),
+		Exits:        FromGRPCExits(req.Exits),
+	}
+
+	if req.Voxel {
+		g.Connectivity = connectivities[req.Connectivity]
+	}
+
+	return g
+}
+
+func ToGRPCMapInfo(info findpath.MapInfo) *findpathv1.MapInfo {
+	return &findpathv1.MapInfo{
+		MapId:      info.ID,
+		Width:      info.Width,
+		Height:     info.Height,
+		Depth:      info.Depth,
+		Cells:      info.Cells,
+		UploadedAt: timestamppb.New(info.Added),
+		LastUsedAt: timestamppb.New(info.LastUsed),
+		Version:    info.Version,
+	}
+}
+
+func FromGRPCPatch(req *findpathv1.PatchMapRequest) *findpath.MapPatch {
+	p := &findpath.MapPatch{
+		Cells: make([]*findpath.CellPatch, len(req.Cells)),
+		Rects: make([]*findpath.RectPatch, len(req.Rects)),
+	}
+
+	for i, c := range req.Cells {
+		p.Cells[i] = &findpath.CellPatch{Node: fromGRPCNode(c.Cell), Value: c.Value}
+	}
+
+	for i, r := range req.Rects {
+		p.Rects[i] = &findpath.RectPatch{From: fromGRPCNode(r.From), To: fromGRPCNode(r.To), Value: r.Value}
+	}
+
+	return p
+}
@@ -0,0 +1,177 @@
+package app_grpc
+
+import (
+	"github.com/unomns/findpath/pkg/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var algorithmsV2 = map[findpathv2.Algorithm]string{
+	findpathv2.Algorithm_ALGORITHM_UNSPECIFIED: findpath.AlgoAStar,
+	findpathv2.Algorithm_ALGORITHM_A_STAR:      findpath.AlgoAStar,
+	findpathv2.Algorithm_ALGORITHM_DIJKSTRA:    "dijkstra",
+	findpathv2.Algorithm_ALGORITHM_BFS:         "bfs",
+	findpathv2.Algorithm_ALGORITHM_JPS:         findpath.AlgoJumpPoint,
+}
+
+var heuristicsV2 = map[findpathv2.Heuristic]findpath.Heuristic{
+	findpathv2.Heuristic_HEURISTIC_UNSPECIFIED: findpath.HeuristicDistance,
+	findpathv2.Heuristic_HEURISTIC_DISTANCE:    findpath.HeuristicDistance,
+	findpathv2.Heuristic_HEURISTIC_NONE:        findpath.HeuristicNone,
+}
+
+var movementsV2 = map[findpathv2.Movement]findpath.Connectivity{
+	findpathv2.Movement_MOVEMENT_UNSPECIFIED: findpath.ConnectivityLevels,
+	findpathv2.Movement_MOVEMENT_GRID:        findpath.ConnectivityLevels,
+	findpathv2.Movement_MOVEMENT_VOXEL_6:     findpath.Connectivity6,
+	findpathv2.Movement_MOVEMENT_VOXEL_18:    findpath.Connectivity18,
+	findpathv2.Movement_MOVEMENT_VOXEL_26:    findpath.Connectivity26,
+}
+
+var directionsV2 = map[findpathv2.Direction]findpath.Direction{
+	findpathv2.Direction_DIRECTION_NONE:  findpath.DirectionNone,
+	findpathv2.Direction_DIRECTION_UP:    findpath.DirectionUp,
+	findpathv2.Direction_DIRECTION_DOWN:  findpath.DirectionDown,
+	findpathv2.Direction_DIRECTION_LEFT:  findpath.DirectionLeft,
+	findpathv2.Direction_DIRECTION_RIGHT: findpath.DirectionRight,
+}
+
+var wrapsV2 = map[findpathv2.Wrap]findpath.Wrap{
+	findpathv2.Wrap_WRAP_NONE:       findpath.WrapNone,
+	findpathv2.Wrap_WRAP_HORIZONTAL: findpath.WrapHorizontal,
+	findpathv2.Wrap_WRAP_VERTICAL:   findpath.WrapVertical,
+	findpathv2.Wrap_WRAP_BOTH:       findpath.WrapBoth,
+}
+
+var falloffsV2 = map[findpathv2.InfluenceSource_Falloff]findpath.Falloff{
+	findpathv2.InfluenceSource_LINEAR:    findpath.FalloffLinear,
+	findpathv2.InfluenceSource_CONSTANT:  findpath.FalloffConstant,
+	findpathv2.InfluenceSource_QUADRATIC: findpath.FalloffQuadratic,
+}
+
+// FromGRPCV2Grid converts the grid of a request, the movement of its options sets the
+// connectivity.
+func FromGRPCV2Grid(g *findpathv2.Grid, movement findpathv2.Movement) *findpath.Grid {
+	res := &findpath.Grid{
+		Width:        g.Width,
+		Height:       g.Height,
+		Depth:        g.Depth,
+		Cells:        g.Cells,
+		LayerWeights: g.LayerWeights,
+		TurnCost:     g.TurnCost,
+		MaxTurns:     g.MaxTurns,
+		Wrap:         wrapsV2[g.Wrap],
+		Connectivity: movementsV2[movement],
+	}
+
+	for _, l := range g.CostLayers {
+		layer := &findpath.CostLayer{Name: l.Name, Dense: l.Dense}
+
+		for _, c := range l.Cells {
+			layer.Cells = append(layer.Cells, &findpath.CellCost{Node: fromGRPCV2Node(c.Cell), Cost: c.Cost})
+		}
+
+		for _, s := range l.Sources {
+			layer.Sources = append(layer.Sources, &findpath.InfluenceSource{
+				Node:     fromGRPCV2Node(s.Cell),
+				Strength: s.Strength,
+				Radius:   s.Radius,
+				Falloff:  falloffsV2[s.Falloff],
+			})
+		}
+
+		res.CostLayers = append(res.CostLayers, layer)
+	}
+
+	for _, p := range g.Portals {
+		res.Portals = append(res.Portals, &findpath.Portal{From: fromGRPCV2Node(p.From), To: fromGRPCV2Node(p.To), Cost: p.Cost})
+	}
+
+	for _, e := range g.Exits {
+		exits := &findpath.CellExits{Node: fromGRPCV2Node(e.Cell)}
+		for _, d := range e.Allow {
+			exits.Allow = append(exits.Allow, directionsV2[d])
+		}
+
+		res.Exits = append(res.Exits, exits)
+	}
+
+	for _, c := range g.Connectors {
+		connector := &findpath.Connector{Kind: c.Kind, Cost: c.Cost}
+		for _, n := range c.Cells {
+			connector.Cells = append(connector.Cells, fromGRPCV2Node(n))
+		}
+
+		res.Connectors = append(res.Connectors, connector)
+	}
+
+	return res
+}
+
+func FromGRPCV2Players(players []*findpathv2.Player) []*findpath.Player {
+	res := make([]*findpath.Player, len(players))
+
+	for i, p := range players {
+		res[i] = &findpath.Player{
+			ID:       p.Id,
+			Start:    fromGRPCV2Node(p.Start),
+			Target:   fromGRPCV2Node(p.Target),
+			Heading:  directionsV2[p.Heading],
+			Metadata: p.Metadata,
+		}
+	}
+
+	return res
+}
+
+var statusesV2 = map[findpath.Status]findpathv2.Path_Status{
+	findpath.StatusOK:             findpathv2.Path_OK,
+	findpath.StatusNoPath:         findpathv2.Path_NO_PATH,
+	findpath.StatusInvalidStart:   findpathv2.Path_INVALID_START,
+	findpath.StatusInvalidTarget:  findpathv2.Path_INVALID_TARGET,
+	findpath.StatusCancelled:      findpathv2.Path_CANCELLED,
+	findpath.StatusBudgetExceeded: findpathv2.Path_BUDGET_EXCEEDED,
+}
+
+// ToGRPCV2Path converts a path, leaving its stats out unless asked for.
+func ToGRPCV2Path(p *findpath.Path, stats bool) *findpathv2.Path {
+	fp := &findpathv2.Path{
+		PlayerId: p.PlayerID,
+		Found:    p.Found,
+		Partial:  p.Partial,
+		Steps:    toGRPCV2Steps(p.Steps),
+		Status:   statusesV2[p.Status],
+		Message:  p.Message,
+		Metadata: p.Metadata,
+	}
+
+	if stats && p.Stats != nil {
+		fp.Stats = &findpathv2.SearchStats{
+			Cost:           p.Stats.Cost,
+			NodesExpanded:  int64(p.Stats.NodesExpanded),
+			NodesGenerated: int64(p.Stats.NodesGenerated),
+			PeakOpen:       int64(p.Stats.PeakOpen),
+			Duration:       durationpb.New(p.Stats.Duration),
+		}
+	}
+
+	for _, r := range p.Routes {
+		fp.Routes = append(fp.Routes, &findpathv2.Route{Steps: toGRPCV2Steps(r.Steps), Cost: r.Cost})
+	}
+
+	return fp
+}
+
+func toGRPCV2Steps(steps []*findpath.Node) []*findpathv2.Node {
+	res := make([]*findpathv2.Node, len(steps))
+
+	for i, n := range steps {
+		res[i] = &findpathv2.Node{Y: n.Y, X: n.X, Z: n.Z}
+	}
+
+	return res
+}
+
+func fromGRPCV2Node(n *findpathv2.Node) findpath.Node {
+	return findpath.Node{Y: n.GetY(), X: n.GetX(), Z: n.GetZ()}
+}
@@ -0,0 +1,116 @@
+package app_grpc
+
+import (
+	"fmt"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// The adapters look enum values up in maps, so an unknown value would quietly become the
+// zero value of the findpath type. Requests are checked for them first.
+
+type violations []*errdetails.BadRequest_FieldViolation
+
+// enum adds a violation when v isn't one of the known values.
+func enum[E ~int32, T any](vs *violations, field string, v E, known map[E]T) {
+	if _, ok := known[v]; !ok {
+		*vs = append(*vs, &errdetails.BadRequest_FieldViolation{Field: field, Description: fmt.Sprintf("unknown value %d", v)})
+	}
+}
+
+// err is a BadRequest status with the violations, nil without any.
+func (vs violations) err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+
+	return badRequest(vs...)
+}
+
+func checkPlayers(vs *violations, players []*findpathv1.Player) {
+	for i, p := range players {
+		enum(vs, fmt.Sprintf("players[%d].heading", i), p.Heading, directions)
+	}
+}
+
+func checkLayers(vs *violations, layers []*findpathv1.CostLayer) {
+	for i, l := range layers {
+		for k, s := range l.Sources {
+			enum(vs, fmt.Sprintf("cost_layers[%d].sources[%d].falloff", i, k), s.Falloff, falloffs)
+		}
+	}
+}
+
+func checkExits(vs *violations, exits []*findpathv1.CellExits) {
+	for i, e := range exits {
+		for k, d := range e.Allow {
+			enum(vs, fmt.Sprintf("exits[%d].allow[%d]", i, k), d, directions)
+		}
+	}
+}
+
+func checkPathRequest(req *findpathv1.PathRequest) error {
+	var vs violations
+	enum(&vs, "wrap", req.Wrap, wraps)
+	checkLayers(&vs, req.CostLayers)
+	checkExits(&vs, req.Exits)
+	checkPlayers(&vs, req.Players)
+
+	return vs.err()
+}
+
+func checkVolumeRequest(req *findpathv1.VolumeRequest) error {
+	var vs violations
+	enum(&vs, "connectivity", req.Connectivity, connectivities)
+	enum(&vs, "wrap", req.Wrap, wraps)
+	checkPlayers(&vs, req.Players)
+
+	return vs.err()
+}
+
+func checkUploadMap(req *findpathv1.UploadMapRequest) error {
+	var vs violations
+	if req.Voxel {
+		enum(&vs, "connectivity", req.Connectivity, connectivities)
+	}
+
+	enum(&vs, "wrap", req.Wrap, wraps)
+	checkLayers(&vs, req.CostLayers)
+	checkExits(&vs, req.Exits)
+
+	return vs.err()
+}
+
+func checkPathOnMap(req *findpathv1.PathOnMapRequest) error {
+	var vs violations
+	checkPlayers(&vs, req.Players)
+
+	return vs.err()
+}
+
+func checkV2Grid(vs *violations, g *findpathv2.Grid) {
+	enum(vs, "grid.wrap", g.Wrap, wrapsV2)
+
+	for i, l := range g.CostLayers {
+		for k, s := range l.Sources {
+			enum(vs, fmt.Sprintf("grid.cost_layers[%d].sources[%d].falloff", i, k), s.Falloff, falloffsV2)
+		}
+	}
+
+	for i, e := range g.Exits {
+		for k, d := range e.Allow {
+			enum(vs, fmt.Sprintf("grid.exits[%d].allow[%d]", i, k), d, directionsV2)
+		}
+	}
+}
+
+func checkV2Players(players []*findpathv2.Player) error {
+	var vs violations
+	for i, p := range players {
+		enum(&vs, fmt.Sprintf("players[%d].heading", i), p.Heading, directionsV2)
+	}
+
+	return vs.err()
+}
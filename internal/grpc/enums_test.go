@@ -0,0 +1,71 @@
+package app_grpc
+
+import (
+	"context"
+	"testing"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+)
+
+func TestUnknownEnumValues(t *testing.T) {
+	conn := newTestConn(t, ServerOptions{})
+	v1, v2 := findpathv1.NewPathFinderClient(conn), findpathv2.NewPathFinderClient(conn)
+	ctx := context.Background()
+
+	players := []*findpathv1.Player{
+		{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 1}},
+		{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 1}, Heading: 9},
+	}
+
+	_, err := v1.Path(ctx, &findpathv1.PathRequest{
+		Width:   2,
+		Height:  1,
+		Grid:    []int32{0, 0},
+		Wrap:    7,
+		Exits:   []*findpathv1.CellExits{{Cell: &findpathv1.Node{}, Allow: []findpathv1.Direction{findpathv1.Direction_LEFT, 5}}},
+		Players: players,
+	})
+	wantViolations(t, "Path", err, "wrap", "exits[0].allow[1]", "players[1].heading")
+
+	_, err = v1.PathVolume(ctx, &findpathv1.VolumeRequest{Width: 2, Height: 1, Depth: 1, Cells: []int32{0, 0}, Connectivity: 3})
+	wantViolations(t, "PathVolume", err, "connectivity")
+
+	_, err = v2.Path(ctx, &findpathv2.PathRequest{
+		Grid: &findpathv2.Grid{
+			Width:      2,
+			Height:     1,
+			Cells:      []int32{0, 0},
+			CostLayers: []*findpathv2.CostLayer{{Name: "fire", Sources: []*findpathv2.InfluenceSource{{Cell: &findpathv2.Node{}, Falloff: 4}}}},
+		},
+	})
+	wantViolations(t, "v2 Path grid", err, "grid.cost_layers[0].sources[0].falloff")
+
+	_, err = v2.Path(ctx, &findpathv2.PathRequest{
+		Grid:    &findpathv2.Grid{Width: 2, Height: 1, Cells: []int32{0, 0}},
+		Players: []*findpathv2.Player{{Start: &findpathv2.Node{}, Target: &findpathv2.Node{X: 1}, Heading: 6}},
+	})
+	wantViolations(t, "v2 Path players", err, "players[0].heading")
+
+	_, err = v2.Path(ctx, &findpathv2.PathRequest{
+		Grid:    &findpathv2.Grid{Width: 2, Height: 1, Cells: []int32{0, 0}},
+		Options: &findpathv2.SearchOptions{Movement: 8},
+	})
+	wantViolations(t, "v2 Path options", err, "options.movement")
+
+	// the options are checked first, an unknown movement would spoil the checks of the grid
+	badGrid := &findpathv2.PathRequest{
+		Grid:    &findpathv2.Grid{Width: 2, Height: 1, Cells: []int32{0}},
+		Players: []*findpathv2.Player{{Start: &findpathv2.Node{}, Target: &findpathv2.Node{X: 1}, Heading: 6}},
+		Options: &findpathv2.SearchOptions{Movement: 8},
+	}
+
+	_, err = v2.Path(ctx, badGrid)
+	wantViolations(t, "v2 Path options before grid", err, "options.movement")
+
+	stream, err := v2.PathStream(ctx, badGrid)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	wantViolations(t, "v2 PathStream options before grid", err, "options.movement")
+}
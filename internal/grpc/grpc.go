@@ -6,14 +6,35 @@ import (
 	"fmt"
 	"github.com/unomns/findpath/pkg/findpath"
 	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
 )
 
 type Server struct {
 	findpathv1.UnimplementedPathFinderServer
+
+	opts ServerOptions
 }
 
-func NewServer() *Server {
-	return &Server{}
+// ServerOptions holds what the requests of a server share. Nil fields are not shared: the
+// default pool is used and nothing is cached.
+type ServerOptions struct {
+	Pool  *findpath.WorkerPool
+	Cache *findpath.PathCache
+
+	// Maps keeps the maps of UploadMap, the map RPCs are unimplemented without it.
+	Maps *findpath.MapRegistry
+
+	// StreamQueries limits the queries of one StreamPaths stream searched at once, 16 when 0.
+	StreamQueries int
+}
+
+const defaultStreamQueries = 16
+
+func NewServer(o ServerOptions) *Server {
+	return &Server{opts: o}
 }
 
 var (
@@ -27,26 +48,262 @@ func (s *Server) Path(
 ) (*findpathv1.PathResponse, error) {
 	fmt.Println("Processing..")
 
-	width := req.Width
-	height := req.Height
-	grid := req.Grid
-	players := req.Players
+	if err := checkPathRequest(req); err != nil {
+		return nil, err
+	}
 
-	if len(grid) != int(width*height) {
-		return nil, errors.New("grid size does not match width × height")
+	service, err := s.newService()
+	if err != nil {
+		return nil, err
 	}
 
-	service, err := findpath.New(defaultAlgo, debugMode)
+	service.SetKShortestPaths(int(req.KPaths), req.MaxOverlap)
+
+	paths, err := service.GetPathFromGrid(ctx, FromGRPCPathRequest(req), FromGRPCPlayers(req.Players))
 	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &findpathv1.PathResponse{
+		Path: ToGRPCPaths(paths),
+	}, nil
+}
+
+func (s *Server) PathVolume(
+	ctx context.Context,
+	req *findpathv1.VolumeRequest,
+) (*findpathv1.PathResponse, error) {
+	if err := checkVolumeRequest(req); err != nil {
 		return nil, err
 	}
 
-	paths, err := service.GetPathFromFlatGrid(width, height, grid, FromGRPCPlayers(players))
+	service, err := s.newService()
 	if err != nil {
 		return nil, err
 	}
 
+	paths, err := service.GetPathFromGrid(ctx, &findpath.Grid{
+		Width:        req.Width,
+		Height:       req.Height,
+		Depth:        req.Depth,
+		Cells:        req.Cells,
+		Connectivity: connectivities[req.Connectivity],
+		Wrap:         wraps[req.Wrap],
+	}, FromGRPCPlayers(req.Players))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
 	return &findpathv1.PathResponse{
 		Path: ToGRPCPaths(paths),
 	}, nil
 }
+
+func (s *Server) PathNavMesh(
+	ctx context.Context,
+	req *findpathv1.NavMeshRequest,
+) (*findpathv1.NavMeshResponse, error) {
+	mesh, err := FromGRPCNavMesh(req.Vertices, req.Polygons)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := s.newService()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := service.GetPathOnNavMesh(ctx, mesh, FromGRPCNavPlayers(req.Players))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &findpathv1.NavMeshResponse{
+		Paths: ToGRPCNavPaths(paths),
+	}, nil
+}
+
+func (s *Server) UploadMap(
+	ctx context.Context,
+	req *findpathv1.UploadMapRequest,
+) (*findpathv1.UploadMapResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	if err := checkUploadMap(req); err != nil {
+		return nil, err
+	}
+
+	cm, err := findpath.Compile(FromGRPCUploadMap(req), findpath.CompileOptions{Components: req.Components})
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	info, err := s.opts.Maps.Add(cm)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &findpathv1.UploadMapResponse{Map: ToGRPCMapInfo(info)}, nil
+}
+
+func (s *Server) PathOnMap(
+	ctx context.Context,
+	req *findpathv1.PathOnMapRequest,
+) (*findpathv1.PathResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	if err := checkPathOnMap(req); err != nil {
+		return nil, err
+	}
+
+	cm, err := s.opts.Maps.Get(req.MapId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	service, err := s.newService()
+	if err != nil {
+		return nil, err
+	}
+
+	service.SetKShortestPaths(int(req.KPaths), req.MaxOverlap)
+
+	paths, err := service.GetPathOnCompiledMap(ctx, cm, FromGRPCPlayers(req.Players))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &findpathv1.PathResponse{
+		Path:       ToGRPCPaths(paths),
+		MapVersion: cm.Version(),
+	}, nil
+}
+
+func (s *Server) PatchMap(
+	ctx context.Context,
+	req *findpathv1.PatchMapRequest,
+) (*findpathv1.PatchMapResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	info, err := s.opts.Maps.Patch(req.MapId, FromGRPCPatch(req))
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	return &findpathv1.PatchMapResponse{Map: ToGRPCMapInfo(info)}, nil
+}
+
+func (s *Server) DeleteMap(
+	ctx context.Context,
+	req *findpathv1.DeleteMapRequest,
+) (*findpathv1.DeleteMapResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	if err := s.opts.Maps.Delete(req.MapId); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &findpathv1.DeleteMapResponse{}, nil
+}
+
+func (s *Server) ListMaps(
+	ctx context.Context,
+	req *findpathv1.ListMapsRequest,
+) (*findpathv1.ListMapsResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	var res findpathv1.ListMapsResponse
+	for _, info := range s.opts.Maps.List() {
+		res.Maps = append(res.Maps, ToGRPCMapInfo(info))
+	}
+
+	return &res, nil
+}
+
+func (s *Server) newService() (*findpath.FindPathService, error) {
+	service, err := findpath.New(defaultAlgo, debugMode)
+	if err != nil {
+		return nil, err
+	}
+
+	service.SetWorkerPool(s.opts.Pool)
+	service.SetPathCache(s.opts.Cache)
+
+	return service, nil
+}
+
+// statusError gives the errors of the findpath package their gRPC status codes.
+func statusError(err error) error {
+	if fields := findpath.FieldErrors(err); len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, f := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Err.Error()}
+		}
+
+		return badRequest(violations...)
+	}
+
+	switch {
+	case errors.Is(err, findpath.ErrPoolSaturated), errors.Is(err, findpath.ErrMapTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, findpath.ErrMapNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, findpath.ErrPoolClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return err
+	}
+}
+
+// requestError is statusError for calls whose other errors come from bad values in the
+// request.
+func requestError(err error) error {
+	err = statusError(err)
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// badRequest is an InvalidArgument status listing the bad fields in its message and, for
+// clients that read them, in BadRequest details.
+func badRequest(violations ...*errdetails.BadRequest_FieldViolation) error {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.Field + ": " + v.Description
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(msgs, "; "))
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
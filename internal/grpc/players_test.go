@@ -0,0 +1,62 @@
+package app_grpc
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/unomns/findpath/pkg/findpath"
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+)
+
+func TestPlayerIDsAndMetadataEchoed(t *testing.T) {
+	cache := findpath.NewPathCache(findpath.CacheOptions{})
+	conn := newTestConn(t, ServerOptions{Cache: cache})
+	v1, v2 := findpathv1.NewPathFinderClient(conn), findpathv2.NewPathFinderClient(conn)
+	ctx := context.Background()
+
+	// the same searches three times, the later ones come from the cache
+	for round, id := range []string{"scout", "tank", ""} {
+		meta := map[string]string{"squad": id}
+		want := id
+		if want == "" {
+			want = "0"
+		}
+
+		res, err := v1.Path(ctx, &findpathv1.PathRequest{
+			Width:   3,
+			Height:  1,
+			Grid:    []int32{0, 0, 0},
+			Players: []*findpathv1.Player{{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 2}, Id: id, Metadata: meta}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := res.Path[0]; p.PlayerId != want || !maps.Equal(p.Metadata, meta) {
+			t.Errorf("v1 round %d: id %q with metadata %v, want %q with %v", round, p.PlayerId, p.Metadata, want, meta)
+		}
+
+		stream, err := v2.PathStream(ctx, &findpathv2.PathRequest{
+			Grid:    &findpathv2.Grid{Width: 3, Height: 1, Cells: []int32{0, 0, 0}},
+			Players: []*findpathv2.Player{{Start: &findpathv2.Node{}, Target: &findpathv2.Node{X: 2}, Id: id, Metadata: meta}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := msg.Path; p.PlayerId != want || !maps.Equal(p.Metadata, meta) {
+			t.Errorf("v2 stream round %d: id %q with metadata %v, want %q with %v", round, p.PlayerId, p.Metadata, want, meta)
+		}
+	}
+
+	if s := cache.Stats(); s.Hits < 4 {
+		t.Errorf("%d cache hits, want the later rounds from the cache", s.Hits)
+	}
+}
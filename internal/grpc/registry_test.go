@@ -0,0 +1,69 @@
+package app_grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unomns/findpath/pkg/findpath"
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMapRegistry(t *testing.T) {
+	c := findpathv1.NewPathFinderClient(newTestConn(t, ServerOptions{
+		Maps: findpath.NewMapRegistry(findpath.RegistryOptions{MaxMaps: 2, MaxCells: 1000}),
+	}))
+	ctx := context.Background()
+
+	player := []*findpathv1.Player{{Start: &findpathv1.Node{}, Target: &findpathv1.Node{Y: 9, X: 9}}}
+
+	var ids []string
+	for range 3 {
+		res, err := c.UploadMap(ctx, &findpathv1.UploadMapRequest{Width: 10, Height: 10, Grid: make([]int32, 100), Components: true})
+		if err != nil {
+			t.Fatalf("upload: %v", err)
+		}
+
+		ids = append(ids, res.Map.MapId)
+	}
+
+	list, err := c.ListMaps(ctx, &findpathv1.ListMapsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Maps) != 2 {
+		t.Errorf("listed %d maps, want 2", len(list.Maps))
+	}
+
+	_, err = c.PathOnMap(ctx, &findpathv1.PathOnMapRequest{MapId: ids[0], Players: player})
+	wantCode(t, "least recently used map", err, codes.NotFound)
+
+	res, err := c.PathOnMap(ctx, &findpathv1.PathOnMapRequest{MapId: ids[2], KPaths: 2, Players: player})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := res.Path[0]; !p.Found || len(p.Steps) != 19 || len(p.Routes) != 2 {
+		t.Errorf("path on map: found %v, %d steps, %d routes, want found, 19 steps and 2 routes", p.Found, len(p.Steps), len(p.Routes))
+	}
+
+	_, err = c.DeleteMap(ctx, &findpathv1.DeleteMapRequest{MapId: ids[2]})
+	wantCode(t, "delete", err, codes.OK)
+
+	_, err = c.DeleteMap(ctx, &findpathv1.DeleteMapRequest{MapId: ids[2]})
+	wantCode(t, "delete again", err, codes.NotFound)
+
+	_, err = c.UploadMap(ctx, &findpathv1.UploadMapRequest{Width: 100, Height: 100, Grid: make([]int32, 10000)})
+	wantCode(t, "too large", err, codes.ResourceExhausted)
+
+	_, err = c.UploadMap(ctx, &findpathv1.UploadMapRequest{Width: 10, Height: 10, Grid: make([]int32, 5)})
+	wantCode(t, "invalid", err, codes.InvalidArgument)
+}
+
+func TestMapRegistryDisabled(t *testing.T) {
+	c := findpathv1.NewPathFinderClient(newTestConn(t, ServerOptions{}))
+
+	_, err := c.UploadMap(context.Background(), &findpathv1.UploadMapRequest{Width: 1, Height: 1, Grid: []int32{0}})
+	wantCode(t, "upload", err, codes.Unimplemented)
+}
@@ -0,0 +1,77 @@
+package app_grpc
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestConn serves both API versions with the options over an in-memory listener.
+func newTestConn(t *testing.T, o ServerOptions) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	srv := grpc.NewServer()
+	findpathv1.RegisterPathFinderServer(srv, NewServer(o))
+	findpathv2.RegisterPathFinderServer(srv, NewServerV2(o))
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func wantCode(t *testing.T, what string, err error, code codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != code {
+		t.Errorf("%s: code %s (%v), want %s", what, got, err, code)
+	}
+}
+
+// wantViolations checks that err is InvalidArgument with BadRequest violations of exactly
+// the fields.
+func wantViolations(t *testing.T, what string, err error, fields ...string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("%s: code %s (%v), want InvalidArgument", what, st.Code(), err)
+		return
+	}
+
+	var got []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				got = append(got, v.Field)
+			}
+		}
+	}
+
+	if !slices.Equal(got, fields) {
+		t.Errorf("%s: violations of %v, want %v", what, got, fields)
+	}
+}
@@ -0,0 +1,249 @@
+package app_grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/unomns/findpath/pkg/findpath"
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) PathStream(
+	req *findpathv1.PathRequest,
+	stream findpathv1.PathFinder_PathStreamServer,
+) error {
+	ctx := stream.Context()
+
+	if err := checkPathRequest(req); err != nil {
+		return err
+	}
+
+	service, err := s.newService()
+	if err != nil {
+		return err
+	}
+
+	service.SetKShortestPaths(int(req.KPaths), req.MaxOverlap)
+
+	for p, err := range service.PathsFromGrid(ctx, FromGRPCPathRequest(req), FromGRPCPlayers(req.Players)) {
+		if err != nil {
+			return statusError(err)
+		}
+
+		if err := stream.Send(&findpathv1.PathStreamResponse{Path: ToGRPCPath(p)}); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
+
+// StreamPaths runs every query of the stream as soon as it arrives, so a slow query doesn't
+// hold the ones behind it. At most ServerOptions.StreamQueries run at once, the next query
+// is received when one of them is done. The results of all queries share the stream and are
+// sent one at a time; once a send fails, the queries left are cancelled and the stream ends
+// with that error.
+func (s *Server) StreamPaths(stream findpathv1.PathFinder_StreamPathsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sending sync.Mutex
+	var sendErr error
+
+	send := func(r *findpathv1.StreamPathResult) {
+		sending.Lock()
+		defer sending.Unlock()
+
+		if sendErr != nil {
+			return
+		}
+
+		if sendErr = stream.Send(r); sendErr != nil {
+			cancel()
+		}
+	}
+
+	slots := make(chan struct{}, s.streamQueries())
+
+	var queries sync.WaitGroup
+
+	err := receiveQueries(ctx, stream, slots, func(req *findpathv1.StreamPathRequest) {
+		queries.Add(1)
+		go func() {
+			defer queries.Done()
+			defer func() { <-slots }()
+
+			s.streamQuery(ctx, req, send)
+		}()
+	})
+
+	queries.Wait()
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	return err
+}
+
+func (s *Server) streamQueries() int {
+	if s.opts.StreamQueries <= 0 {
+		return defaultStreamQueries
+	}
+
+	return s.opts.StreamQueries
+}
+
+// receiveQueries hands every query of the stream to run, taking a slot before receiving it.
+// It returns nil when the client closes its side, and stops early once ctx is done.
+func receiveQueries(
+	ctx context.Context,
+	stream findpathv1.PathFinder_StreamPathsServer,
+	slots chan struct{},
+	run func(req *findpathv1.StreamPathRequest),
+) error {
+	requests := make(chan *findpathv1.StreamPathRequest)
+	failed := make(chan error, 1)
+
+	// Recv can't be interrupted, it returns once the handler does
+	go func() {
+		for {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			req, err := stream.Recv()
+			if err != nil {
+				failed <- err
+				return
+			}
+
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-requests:
+			run(req)
+		case err := <-failed:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// streamQuery sends a result per player of the query, or a single error when the query
+// fails as a whole. The last message for the query has Last set.
+func (s *Server) streamQuery(
+	ctx context.Context,
+	req *findpathv1.StreamPathRequest,
+	send func(r *findpathv1.StreamPathResult),
+) {
+	if err := s.runQuery(ctx, req, send); err != nil {
+		st := status.Convert(statusError(err))
+
+		send(&findpathv1.StreamPathResult{
+			CorrelationId: req.CorrelationId,
+			PlayerIndex:   -1,
+			Error:         &findpathv1.StreamError{Code: int32(st.Code()), Message: st.Message()},
+			Last:          true,
+		})
+	}
+}
+
+// runQuery searches the paths of the query and sends them as they are found.
+func (s *Server) runQuery(
+	ctx context.Context,
+	req *findpathv1.StreamPathRequest,
+	send func(r *findpathv1.StreamPathResult),
+) error {
+	service, err := s.newService()
+	if err != nil {
+		return err
+	}
+
+	var mapVersion uint64
+	var players, left int
+
+	emit := func(i int, p *findpath.Path) {
+		left--
+		send(&findpathv1.StreamPathResult{
+			CorrelationId: req.CorrelationId,
+			PlayerIndex:   int32(i),
+			Path:          ToGRPCPath(p),
+			Last:          left == 0,
+			MapVersion:    mapVersion,
+		})
+	}
+
+	switch q := req.Query.(type) {
+	case *findpathv1.StreamPathRequest_Path:
+		if err := checkPathRequest(q.Path); err != nil {
+			return err
+		}
+
+		players = len(q.Path.Players)
+		left = players
+		service.SetKShortestPaths(int(q.Path.KPaths), q.Path.MaxOverlap)
+
+		err = service.StreamPathsFromGrid(ctx, FromGRPCPathRequest(q.Path), FromGRPCPlayers(q.Path.Players), emit)
+	case *findpathv1.StreamPathRequest_OnMap:
+		if s.opts.Maps == nil {
+			return status.Error(codes.Unimplemented, "map registry is disabled")
+		}
+
+		if err := checkPathOnMap(q.OnMap); err != nil {
+			return err
+		}
+
+		var cm *findpath.CompiledMap
+		if cm, err = s.opts.Maps.Get(q.OnMap.MapId); err != nil {
+			return err
+		}
+
+		players = len(q.OnMap.Players)
+		left = players
+		mapVersion = cm.Version()
+		service.SetKShortestPaths(int(q.OnMap.KPaths), q.OnMap.MaxOverlap)
+
+		err = service.StreamPathsOnCompiledMap(ctx, cm, FromGRPCPlayers(q.OnMap.Players), emit)
+	default:
+		return status.Error(codes.InvalidArgument, "query is not set")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if players == 0 {
+		// No players, still tell the client the query is done.
+		send(&findpathv1.StreamPathResult{
+			CorrelationId: req.CorrelationId,
+			PlayerIndex:   -1,
+			Last:          true,
+			MapVersion:    mapVersion,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package app_grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// streamPaths sends the queries on one StreamPaths stream, closes it and returns every
+// result in the order they came.
+func streamPaths(t *testing.T, o ServerOptions, queries ...*findpathv1.StreamPathRequest) []*findpathv1.StreamPathResult {
+	t.Helper()
+
+	stream, err := findpathv1.NewPathFinderClient(newTestConn(t, o)).StreamPaths(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, q := range queries {
+		if err := stream.Send(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	var res []*findpathv1.StreamPathResult
+	for {
+		r, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return res
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res = append(res, r)
+	}
+}
+
+// corridorQuery asks for n players walking a corridor of 3 cells.
+func corridorQuery(id string, n int) *findpathv1.StreamPathRequest {
+	players := make([]*findpathv1.Player, n)
+	for i := range players {
+		players[i] = &findpathv1.Player{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 2}}
+	}
+
+	return &findpathv1.StreamPathRequest{
+		CorrelationId: id,
+		Query:         &findpathv1.StreamPathRequest_Path{Path: &findpathv1.PathRequest{Width: 3, Height: 1, Grid: []int32{0, 0, 0}, Players: players}},
+	}
+}
+
+func TestStreamPaths(t *testing.T) {
+	bad := corridorQuery("bad", 1)
+	bad.GetPath().Grid = []int32{0, 0}
+
+	res := streamPaths(t, ServerOptions{}, corridorQuery("corridor", 3), bad, corridorQuery("empty", 0))
+
+	byQuery := make(map[string][]*findpathv1.StreamPathResult)
+	for _, r := range res {
+		byQuery[r.CorrelationId] = append(byQuery[r.CorrelationId], r)
+	}
+
+	if len(byQuery) != 3 {
+		t.Fatalf("results for %d correlation ids, want 3", len(byQuery))
+	}
+
+	// every player once, in any order, the last result of the query marked
+	corridor := byQuery["corridor"]
+	seen := make(map[int32]bool)
+	for k, r := range corridor {
+		if r.Last != (k == len(corridor)-1) {
+			t.Errorf("corridor result %d of %d: last %v", k, len(corridor), r.Last)
+		}
+
+		if r.Error != nil || !r.Path.GetFound() || seen[r.PlayerIndex] || r.PlayerIndex < 0 || r.PlayerIndex > 2 {
+			t.Errorf("corridor: player %d found %v with error %v", r.PlayerIndex, r.Path.GetFound(), r.Error)
+		}
+
+		seen[r.PlayerIndex] = true
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("corridor: results for players %v, want 0, 1 and 2", seen)
+	}
+
+	// a query failing as a whole gets a single error
+	if rs := byQuery["bad"]; len(rs) != 1 {
+		t.Errorf("bad: %d results, want a single error", len(rs))
+	} else if r := rs[0]; r.PlayerIndex != -1 || !r.Last || r.Path != nil || codes.Code(r.Error.GetCode()) != codes.InvalidArgument {
+		t.Errorf("bad: player %d, last %v, path %v, error %v, want -1, last, no path and InvalidArgument", r.PlayerIndex, r.Last, r.Path, r.Error)
+	}
+
+	// a query without players is done at once
+	if rs := byQuery["empty"]; len(rs) != 1 {
+		t.Errorf("empty: %d results, want one", len(rs))
+	} else if r := rs[0]; r.PlayerIndex != -1 || !r.Last || r.Path != nil || r.Error != nil {
+		t.Errorf("empty: player %d, last %v, path %v, error %v, want -1, last, no path and no error", r.PlayerIndex, r.Last, r.Path, r.Error)
+	}
+}
+
+// With one query at a time, the next query is only received once the results of the one
+// before are all sent.
+func TestStreamPathsLimitsQueries(t *testing.T) {
+	res := streamPaths(t, ServerOptions{StreamQueries: 1}, corridorQuery("a", 2), corridorQuery("b", 2), corridorQuery("c", 2))
+
+	var got []string
+	for _, r := range res {
+		got = append(got, r.CorrelationId)
+	}
+
+	if want := []string{"a", "a", "b", "b", "c", "c"}; !slices.Equal(got, want) {
+		t.Errorf("results of %v, want %v", got, want)
+	}
+}
+
+// failingStream is a StreamPaths stream whose sends fail. Its client never closes its side.
+type failingStream struct {
+	grpc.ServerStream
+
+	ctx     context.Context
+	queries chan *findpathv1.StreamPathRequest
+	sends   int
+}
+
+var errSend = errors.New("send failed")
+
+func (s *failingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *failingStream) Recv() (*findpathv1.StreamPathRequest, error) {
+	select {
+	case q := <-s.queries:
+		return q, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *failingStream) Send(*findpathv1.StreamPathResult) error {
+	s.sends++ // sends never overlap
+	return errSend
+}
+
+func TestStreamPathsSendFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &failingStream{ctx: ctx, queries: make(chan *findpathv1.StreamPathRequest, 1)}
+	stream.queries <- corridorQuery("corridor", 50)
+
+	done := make(chan error)
+	go func() {
+		done <- NewServer(ServerOptions{}).StreamPaths(stream)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errSend) {
+			t.Errorf("got %v, want the send error", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the stream didn't end after a failed send")
+	}
+
+	if stream.sends != 1 {
+		t.Errorf("%d sends, want none after the failed one", stream.sends)
+	}
+}
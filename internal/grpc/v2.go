@@ -0,0 +1,281 @@
+package app_grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/unomns/findpath/pkg/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerV2 serves version 2 of the API. Created with the options of the version 1 server, it
+// shares its pool, cache and uploaded maps.
+type ServerV2 struct {
+	findpathv2.UnimplementedPathFinderServer
+
+	opts ServerOptions
+}
+
+func NewServerV2(o ServerOptions) *ServerV2 {
+	return &ServerV2{opts: o}
+}
+
+func (s *ServerV2) Path(
+	ctx context.Context,
+	req *findpathv2.PathRequest,
+) (*findpathv2.PathResponse, error) {
+	if err := validateOptionsV2(req.Options); err != nil {
+		return nil, err
+	}
+
+	if err := validateGridV2(req.Grid, req.Options.GetMovement()); err != nil {
+		return nil, err
+	}
+
+	if err := checkV2Players(req.Players); err != nil {
+		return nil, err
+	}
+
+	service, searchCtx, cancel, err := s.newSearch(ctx, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	paths, err := service.GetPathFromGrid(
+		searchCtx,
+		FromGRPCV2Grid(req.Grid, req.Options.GetMovement()),
+		FromGRPCV2Players(req.Players),
+	)
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	res := &findpathv2.PathResponse{}
+	for _, p := range paths {
+		res.Paths = append(res.Paths, ToGRPCV2Path(p, req.Options.GetStats()))
+	}
+
+	return res, nil
+}
+
+func (s *ServerV2) PathStream(
+	req *findpathv2.PathRequest,
+	stream findpathv2.PathFinder_PathStreamServer,
+) error {
+	ctx := stream.Context()
+
+	if err := validateOptionsV2(req.Options); err != nil {
+		return err
+	}
+
+	if err := validateGridV2(req.Grid, req.Options.GetMovement()); err != nil {
+		return err
+	}
+
+	if err := checkV2Players(req.Players); err != nil {
+		return err
+	}
+
+	service, searchCtx, cancel, err := s.newSearch(ctx, req.Options)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	paths := service.PathsFromGrid(
+		searchCtx,
+		FromGRPCV2Grid(req.Grid, req.Options.GetMovement()),
+		FromGRPCV2Players(req.Players),
+	)
+
+	for p, err := range paths {
+		if err != nil {
+			return requestError(err)
+		}
+
+		if err := stream.Send(&findpathv2.PathStreamResponse{Path: ToGRPCV2Path(p, req.Options.GetStats())}); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
+
+func (s *ServerV2) PathOnMap(
+	ctx context.Context,
+	req *findpathv2.PathOnMapRequest,
+) (*findpathv2.PathResponse, error) {
+	if s.opts.Maps == nil {
+		return nil, status.Error(codes.Unimplemented, "map registry is disabled")
+	}
+
+	if err := validateOptionsV2(req.Options); err != nil {
+		return nil, err
+	}
+
+	if req.Options.GetMovement() != findpathv2.Movement_MOVEMENT_UNSPECIFIED {
+		return nil, badRequest(&errdetails.BadRequest_FieldViolation{
+			Field:       "options.movement",
+			Description: "the movement of a map is set when it is uploaded",
+		})
+	}
+
+	if err := checkV2Players(req.Players); err != nil {
+		return nil, err
+	}
+
+	cm, err := s.opts.Maps.Get(req.MapId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	service, searchCtx, cancel, err := s.newSearch(ctx, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	paths, err := service.GetPathOnCompiledMap(searchCtx, cm, FromGRPCV2Players(req.Players))
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	res := &findpathv2.PathResponse{MapVersion: cm.Version()}
+	for _, p := range paths {
+		res.Paths = append(res.Paths, ToGRPCV2Path(p, req.Options.GetStats()))
+	}
+
+	return res, nil
+}
+
+// newSearch returns a service set up with options checked by validateOptionsV2. The context
+// has the timeout of the options, players still searching when it expires are cancelled.
+func (s *ServerV2) newSearch(
+	ctx context.Context,
+	o *findpathv2.SearchOptions,
+) (*findpath.FindPathService, context.Context, context.CancelFunc, error) {
+	service, err := findpath.New(algorithmsV2[o.GetAlgorithm()], debugMode)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	service.SetWorkerPool(s.opts.Pool)
+	service.SetPathCache(s.opts.Cache)
+	service.SetKShortestPaths(int(o.GetKPaths()), o.GetMaxOverlap())
+	service.SetHeuristic(heuristicsV2[o.GetHeuristic()], o.GetHeuristicWeight())
+	service.SetPartialPaths(o.GetPartialPaths())
+	service.SetMaxExpansions(int(o.GetLimits().GetMaxExpansions()))
+	service.SetMaxMemory(o.GetLimits().GetMaxMemory())
+
+	if timeout := o.GetLimits().GetTimeout(); timeout != nil {
+		searchCtx, cancel := context.WithTimeout(ctx, timeout.AsDuration())
+		return service, searchCtx, cancel, nil
+	}
+
+	searchCtx, cancel := context.WithCancel(ctx)
+
+	return service, searchCtx, cancel, nil
+}
+
+// validateOptionsV2 rejects options the services can't honour. Unset options are valid.
+func validateOptionsV2(o *findpathv2.SearchOptions) error {
+	invalid := func(field string, format string, args ...any) error {
+		return badRequest(&errdetails.BadRequest_FieldViolation{Field: "options." + field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if _, ok := algorithmsV2[o.GetAlgorithm()]; !ok {
+		return invalid("algorithm", "unknown algorithm %d", o.GetAlgorithm())
+	}
+
+	if _, ok := heuristicsV2[o.GetHeuristic()]; !ok {
+		return invalid("heuristic", "unknown heuristic %d", o.GetHeuristic())
+	}
+
+	if _, ok := movementsV2[o.GetMovement()]; !ok {
+		return invalid("movement", "unknown movement %d", o.GetMovement())
+	}
+
+	if w := o.GetHeuristicWeight(); !(w >= 0) || math.IsInf(w, 0) {
+		return invalid("heuristic_weight", "must be a finite number >= 0, got %v", w)
+	}
+
+	aStar := algorithmsV2[o.GetAlgorithm()] == findpath.AlgoAStar || algorithmsV2[o.GetAlgorithm()] == findpath.AlgoJumpPoint
+	tuned := o.GetHeuristic() != findpathv2.Heuristic_HEURISTIC_UNSPECIFIED || o.GetHeuristicWeight() != 0
+
+	switch {
+	case tuned && !aStar:
+		return invalid("heuristic", "only A* uses a heuristic")
+	case o.GetPartialPaths() && !aStar:
+		return invalid("partial_paths", "only A* returns partial paths")
+	case tuned && o.GetKPaths() > 1:
+		return invalid("heuristic", "alternative routes are not searched with A*")
+	case o.GetPartialPaths() && o.GetKPaths() > 1:
+		return invalid("partial_paths", "not supported with alternative routes")
+	}
+
+	if o.GetKPaths() < 0 {
+		return invalid("k_paths", "must be >= 0, got %d", o.GetKPaths())
+	}
+
+	if v := o.GetMaxOverlap(); !(v >= 0 && v <= 1) {
+		return invalid("max_overlap", "must be within 0..1, got %v", v)
+	}
+
+	limits := o.GetLimits()
+
+	if limits.GetMaxExpansions() < 0 || limits.GetMaxExpansions() > math.MaxInt32 {
+		return invalid("limits.max_expansions", "must be within 0..%d, got %d", math.MaxInt32, limits.GetMaxExpansions())
+	}
+
+	if limits.GetMaxMemory() < 0 {
+		return invalid("limits.max_memory", "must be >= 0, got %d", limits.GetMaxMemory())
+	}
+
+	if t := limits.GetTimeout(); t != nil && (t.CheckValid() != nil || t.AsDuration() <= 0) {
+		return invalid("limits.timeout", "must be a positive duration")
+	}
+
+	return nil
+}
+
+// validateGridV2 checks that the request has a grid, that its enum values are known and that
+// its features go with the movement. The findpath package checks the rest.
+func validateGridV2(g *findpathv2.Grid, movement findpathv2.Movement) error {
+	if g == nil {
+		return badRequest(&errdetails.BadRequest_FieldViolation{Field: "grid", Description: "is required"})
+	}
+
+	var vs violations
+	checkV2Grid(&vs, g)
+
+	if err := vs.err(); err != nil {
+		return err
+	}
+
+	voxel := movementsV2[movement] != findpath.ConnectivityLevels
+	if voxel && (g.TurnCost != 0 || g.MaxTurns != 0 || len(g.Exits) > 0) {
+		return badRequest(&errdetails.BadRequest_FieldViolation{
+			Field:       "options.movement",
+			Description: "turn rules and exits need grid movement",
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package app_grpc
+
+import (
+	"context"
+	"testing"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+)
+
+func TestInvalidGridViolations(t *testing.T) {
+	conn := newTestConn(t, ServerOptions{})
+	v1, v2 := findpathv1.NewPathFinderClient(conn), findpathv2.NewPathFinderClient(conn)
+	ctx := context.Background()
+
+	// 0 0 1
+	players := []*findpathv1.Player{{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 1}}}
+	req := func() *findpathv1.PathRequest {
+		return &findpathv1.PathRequest{Width: 3, Height: 1, Grid: []int32{0, 0, 1}, Players: players}
+	}
+
+	r := req()
+	r.Grid = r.Grid[:2]
+	_, err := v1.Path(ctx, r)
+	wantViolations(t, "short grid", err, "grid.cells")
+
+	r = req()
+	r.Portals = []*findpathv1.Portal{{From: &findpathv1.Node{}, To: &findpathv1.Node{X: 2}}}
+	_, err = v1.Path(ctx, r)
+	wantViolations(t, "portal to a wall", err, "grid.portals[0]")
+
+	r = req()
+	r.CostLayers = []*findpathv1.CostLayer{{Name: "mud", Cells: []*findpathv1.CellCost{{Cell: &findpathv1.Node{}}, {Cell: &findpathv1.Node{X: 5}}}}}
+	_, err = v1.Path(ctx, r)
+	wantViolations(t, "cost cell off the map", err, "grid.cost_layers[0].cells[1]")
+
+	r = req()
+	r.Exits = []*findpathv1.CellExits{{Cell: &findpathv1.Node{Y: 1}}}
+	_, err = v1.Path(ctx, r)
+	wantViolations(t, "exits off the map", err, "grid.exits[0]")
+
+	stream, err := v1.PathStream(ctx, r)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	wantViolations(t, "PathStream", err, "grid.exits[0]")
+
+	_, err = v2.Path(ctx, &findpathv2.PathRequest{
+		Grid: &findpathv2.Grid{
+			Width:      3,
+			Height:     1,
+			Cells:      []int32{0, 0, 1},
+			Connectors: []*findpathv2.Connector{{Cells: []*findpathv2.Node{{}, {X: 3}}, Cost: 1}},
+		},
+	})
+	wantViolations(t, "v2 connector off the map", err, "grid.connectors[0]")
+}
+
+func TestPlayerStatuses(t *testing.T) {
+	conn := newTestConn(t, ServerOptions{})
+	client := findpathv1.NewPathFinderClient(conn)
+
+	// 0 0 1 0
+	// 0 1 1 0
+	res, err := client.Path(context.Background(), &findpathv1.PathRequest{
+		Width:  4,
+		Height: 2,
+		Grid:   []int32{0, 0, 1, 0, 0, 1, 1, 0},
+		Players: []*findpathv1.Player{
+			{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 1}},
+			{Start: &findpathv1.Node{}, Target: &findpathv1.Node{X: 3}},
+			{Start: &findpathv1.Node{X: 2}, Target: &findpathv1.Node{}},
+			{Start: &findpathv1.Node{}, Target: &findpathv1.Node{Y: 5}},
+			{Start: &findpathv1.Node{X: -1}, Target: &findpathv1.Node{X: 2}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []findpathv1.Path_Status{
+		findpathv1.Path_OK,
+		findpathv1.Path_NO_PATH,
+		findpathv1.Path_INVALID_START,
+		findpathv1.Path_INVALID_TARGET,
+		findpathv1.Path_INVALID_START,
+	}
+	if len(res.Path) != len(want) {
+		t.Fatalf("%d paths, want %d", len(res.Path), len(want))
+	}
+
+	for i, p := range res.Path {
+		if p.Status != want[i] {
+			t.Errorf("player %d: status %s (%q), want %s", i, p.Status, p.Message, want[i])
+		}
+
+		if p.Found != (want[i] == findpathv1.Path_OK) {
+			t.Errorf("player %d: found %v with status %s", i, p.Found, p.Status)
+		}
+
+		if (p.Message == "") != (want[i] == findpathv1.Path_OK) {
+			t.Errorf("player %d: message %q with status %s", i, p.Message, p.Status)
+		}
+	}
+}
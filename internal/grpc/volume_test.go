@@ -0,0 +1,60 @@
+package app_grpc
+
+import (
+	"context"
+	"testing"
+
+	findpathv1 "github.com/unomns/findpath/protos/gen/findpath"
+	findpathv2 "github.com/unomns/findpath/protos/gen/findpath/v2"
+)
+
+func TestPathVolume(t *testing.T) {
+	conn := newTestConn(t, ServerOptions{})
+	v1, v2 := findpathv1.NewPathFinderClient(conn), findpathv2.NewPathFinderClient(conn)
+	ctx := context.Background()
+
+	// from corner to corner of an open 3×3×3 cube
+	players := []*findpathv1.Player{{Start: &findpathv1.Node{}, Target: &findpathv1.Node{Z: 2, Y: 2, X: 2}}}
+	req := func(c findpathv1.Connectivity) *findpathv1.VolumeRequest {
+		return &findpathv1.VolumeRequest{Width: 3, Height: 3, Depth: 3, Cells: make([]int32, 27), Connectivity: c, Players: players}
+	}
+
+	for c, cost := range map[findpathv1.Connectivity]int32{
+		findpathv1.Connectivity_CONNECTIVITY_6:  6,
+		findpathv1.Connectivity_CONNECTIVITY_18: 3,
+		findpathv1.Connectivity_CONNECTIVITY_26: 2,
+	} {
+		res, err := v1.PathVolume(ctx, req(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := res.Path[0]; !p.Found || p.Stats.GetCost() != cost || len(p.Steps) != int(cost)+1 {
+			t.Errorf("%s: found %v with cost %d and %d steps, want cost %d", c, p.Found, p.Stats.GetCost(), len(p.Steps), cost)
+		}
+	}
+
+	r := req(findpathv1.Connectivity_CONNECTIVITY_26)
+	r.Cells = r.Cells[:18]
+	_, err := v1.PathVolume(ctx, r)
+	wantViolations(t, "two levels of cells for three", err, "grid.cells")
+
+	r = req(findpathv1.Connectivity_CONNECTIVITY_26)
+	r.Depth = 0
+	_, err = v1.PathVolume(ctx, r)
+	wantViolations(t, "no depth", err, "grid.cells")
+
+	// jump search over v2
+	res, err := v2.Path(ctx, &findpathv2.PathRequest{
+		Grid:    &findpathv2.Grid{Width: 3, Height: 3, Depth: 3, Cells: make([]int32, 27)},
+		Players: []*findpathv2.Player{{Start: &findpathv2.Node{}, Target: &findpathv2.Node{Z: 2, Y: 2, X: 2}}},
+		Options: &findpathv2.SearchOptions{Algorithm: findpathv2.Algorithm_ALGORITHM_JPS, Movement: findpathv2.Movement_MOVEMENT_VOXEL_26, Stats: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := res.Paths[0]; !p.Found || p.Stats.GetCost() != 2 {
+		t.Errorf("v2 jump search: found %v with cost %d, want cost 2", p.Found, p.Stats.GetCost())
+	}
+}
@@ -6,15 +6,164 @@ type GameMap struct {
 	Grid    [][]int32 `json:"grid"`
 	Players []Player  `json:"players"`
 	Map     []Node    `json:"map"`
+
+	// Levels stacks several grids as z-levels. A map with a single Grid is turned into
+	// one level when prepared, and Grid always points to level 0.
+	Levels     Volume      `json:"levels"`
+	Connectors []Connector `json:"connectors"` // stairs and elevators between levels
+
+	// Connectivity turns Levels into a voxel grid where cells of neighbouring levels are adjacent.
+	Connectivity Connectivity `json:"connectivity"`
+
+	CostLayers   []CostLayer        `json:"cost_layers"`
+	LayerWeights map[string]float64 `json:"layer_weights"` // by layer name, missing layers weigh 1
+
+	TurnCost int32 `json:"turn_cost"` // added for every 90° change of direction
+	MaxTurns int32 `json:"max_turns"` // max 90° direction changes per path, 0 - no limit
+
+	Wrap    Wrap        `json:"wrap"` // edges that connect to the opposite side
+	Portals []Portal    `json:"portals"`
+	Exits   []CellExits `json:"exits"` // cells that can only be left in some directions
+
+	// ExtraCost is the blend of all cost layers, a flat Depth*Width*Height array added to the
+	// cost of entering each cell. Nil when the map has no layers.
+	ExtraCost []int32 `json:"-"`
+	// ExitMask is a flat Depth*Width*Height array of allowed exit directions. Nil when all are allowed.
+	ExitMask []uint8 `json:"-"`
+	// PortalsFrom indexes Portals and the edges of Connectors by their entry cell.
+	PortalsFrom map[Node][]Portal `json:"-"`
+	// PortalDistance and ExitDistance hold the number of steps from every cell to the nearest
+	// portal entry and exit, walls ignored, and MinPortalCost is the cost of the cheapest
+	// portal. The arrays are flat Width*Height arrays, Depth*Width*Height on voxel grids. Nil
+	// without portals.
+	PortalDistance []int32 `json:"-"`
+	ExitDistance   []int32 `json:"-"`
+	MinPortalCost  int32   `json:"-"`
+	// Components is a flat Depth*Width*Height array of region labels, -1 for blocked cells,
+	// and ComponentSizes counts the cells of each region. Nil until the map is labelled.
+	Components     []int32 `json:"-"`
+	ComponentSizes []int32 `json:"-"`
+	// Moves is a flat Depth*Width*Height array of the steps each cell allows, one bit per
+	// offset to the surrounding cells. Nil unless the map is compiled for repeated queries.
+	Moves []uint32 `json:"-"`
+	// Clearance is a flat Depth*Width*Height array holding the side of the largest walkable
+	// square with the cell as its top-left corner. Nil unless requested when compiling.
+	Clearance []int32 `json:"-"`
+}
+
+// Depth returns the number of levels.
+func (m *GameMap) Depth() int32 {
+	if len(m.Levels) == 0 {
+		return 1
+	}
+
+	return int32(len(m.Levels))
+}
+
+// Volume is a 3D grid indexed as [z][y][x], 0 is walkable.
+type Volume [][][]int32
+
+func (v Volume) At(n Node) int32 {
+	return v[n.Z][n.Y][n.X]
 }
 
+// Connectivity is the number of neighbours of a voxel.
+type Connectivity int32
+
+const (
+	ConnectivityLevels Connectivity = 0  // 4 neighbours within a level, levels joined by connectors only
+	Connectivity6      Connectivity = 6  // faces
+	Connectivity18     Connectivity = 18 // faces and edges
+	Connectivity26     Connectivity = 26 // faces, edges and corners
+)
+
 type Node struct {
 	Y int32 `json:"y"`
 	X int32 `json:"x"`
+	Z int32 `json:"z,omitempty"` // level, 0 on single level maps
 }
 
 type Player struct {
-	ID     int
-	Start  Node
-	Target Node
+	ID       string // the index of the player when empty
+	Start    Node
+	Target   Node
+	Heading  Direction // the player can start moving anywhere when empty
+	Metadata map[string]string
+}
+
+type Wrap string
+
+const (
+	WrapNone       Wrap = ""
+	WrapHorizontal Wrap = "horizontal" // x = 0 and x = Width-1 are adjacent
+	WrapVertical   Wrap = "vertical"   // y = 0 and y = Height-1 are adjacent
+	WrapBoth       Wrap = "both"
+)
+
+type Direction string
+
+const (
+	DirectionNone  Direction = ""
+	DirectionUp    Direction = "up" // towards y = 0
+	DirectionDown  Direction = "down"
+	DirectionLeft  Direction = "left" // towards x = 0
+	DirectionRight Direction = "right"
+)
+
+// Portal is a directed edge between any two cells: a teleport pad, a ladder or a door.
+type Portal struct {
+	From Node  `json:"from"`
+	To   Node  `json:"to"`
+	Cost int32 `json:"cost"`
+}
+
+// Connector links cells on different levels, e.g. both ends of stairs or every floor of an
+// elevator shaft. Each pair of its cells is connected both ways, Cost is paid per level travelled.
+type Connector struct {
+	Kind  string `json:"kind"` // informational, e.g. "stairs" or "elevator"
+	Cells []Node `json:"cells"`
+	Cost  int32  `json:"cost"`
+}
+
+// CellExits limits the directions a cell can be left in, e.g. for conveyor tiles.
+// Portals starting at the cell are not affected.
+type CellExits struct {
+	Y     int32       `json:"y"`
+	X     int32       `json:"x"`
+	Z     int32       `json:"z"`
+	Allow []Direction `json:"allow"`
+}
+
+// CostLayer adds extra cost to cells without making them impassable.
+// Cells, Dense and Sources may be combined within one layer.
+type CostLayer struct {
+	Name    string            `json:"name"`
+	Cells   []CellCost        `json:"cells"`   // sparse
+	Dense   []int32           `json:"dense"`   // flat Depth*Width*Height array
+	Sources []InfluenceSource `json:"sources"` // radial, spread within their own level
+}
+
+type CellCost struct {
+	Y    int32 `json:"y"`
+	X    int32 `json:"x"`
+	Z    int32 `json:"z"`
+	Cost int32 `json:"cost"`
+}
+
+type Falloff string
+
+const (
+	FalloffConstant  Falloff = "constant"
+	FalloffLinear    Falloff = "linear"
+	FalloffQuadratic Falloff = "quadratic"
+)
+
+// InfluenceSource spreads Strength around a cell, fading out to nothing at Radius.
+type InfluenceSource struct {
+	Y        int32   `json:"y"`
+	X        int32   `json:"x"`
+	Z        int32   `json:"z"`
+	Strength int32   `json:"strength"`
+	Radius   int32   `json:"radius"`
+	Falloff  Falloff `json:"falloff"` // linear by default
 }
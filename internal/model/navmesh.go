@@ -0,0 +1,41 @@
+package model
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// NavMesh is a set of convex polygons. Polygons sharing an edge, that is both of its
+// vertex indices, are connected through it.
+type NavMesh struct {
+	Vertices []Point     `json:"vertices"`
+	Polygons [][]int32   `json:"polygons"` // vertex indices of each polygon, in order
+	Players  []NavPlayer `json:"players"`
+
+	// Portals lists the edges shared by two polygons. It is rebuilt when the mesh is prepared,
+	// so it only matters to tools reading an exported mesh.
+	Portals []NavPortal `json:"portals,omitempty"`
+
+	// Links lists the neighbours of every polygon, built when the mesh is prepared.
+	Links [][]NavLink `json:"-"`
+}
+
+// NavLink is an edge shared by two polygons. Left and Right are vertex indices as seen
+// when crossing the edge from the polygon owning the link.
+type NavLink struct {
+	To    int32
+	Left  int32
+	Right int32
+}
+
+type NavPortal struct {
+	Polygons [2]int32 `json:"polygons"`
+	Edge     [2]int32 `json:"edge"` // vertex indices
+}
+
+type NavPlayer struct {
+	ID       string // the index of the player when empty
+	Start    Point
+	Target   Point
+	Metadata map[string]string
+}
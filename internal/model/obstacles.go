@@ -0,0 +1,12 @@
+package model
+
+// ObstacleMap is open continuous space with polygonal obstacles, searched over a visibility graph.
+type ObstacleMap struct {
+	Obstacles [][]Point   `json:"obstacles"` // simple polygons, walking along their edges is allowed
+	Players   []NavPlayer `json:"players"`
+
+	// Vertices holds the obstacle corners and Visible[i] the corners seen from Vertices[i].
+	// Both are built when the map is prepared.
+	Vertices []Point   `json:"-"`
+	Visible  [][]int32 `json:"-"`
+}
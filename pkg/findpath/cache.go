@@ -0,0 +1,254 @@
+package findpath
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// CacheOptions limits a PathCache. Zero values mean unlimited.
+type CacheOptions struct {
+	MaxEntries int
+	TTL        time.Duration // how long a path stays valid after it was found
+}
+
+// CacheStats counts the lookups of a PathCache.
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"` // entries dropped for space or age, invalidations excluded
+	Entries   int    `json:"entries"`
+}
+
+// PathCache keeps the results of recent searches, least recently used first out. Entries are
+// keyed by a fingerprint of the map content, the algorithm and limits of the service, and the
+// player's start, target and heading, so a changed map never gets stale paths. One cache can
+// be shared by any number of services.
+type PathCache struct {
+	opts CacheOptions
+
+	mu      sync.Mutex
+	entries *list.List // of *cacheEntry, most recently used first
+	byKey   map[cacheKey]*list.Element
+	stats   CacheStats
+}
+
+// mapFingerprint is a hash of everything in a map that affects searches.
+type mapFingerprint [sha256.Size]byte
+
+type cacheKey struct {
+	fingerprint mapFingerprint
+
+	algo          string
+	maxExpansions int
+	maxMemory     int64
+	kPaths        int
+	maxOverlap    float64
+
+	heuristic       Heuristic
+	heuristicWeight float64
+	partialPaths    bool
+
+	start   model.Node
+	target  model.Node
+	heading model.Direction
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	path  *Path
+	added time.Time
+}
+
+func NewPathCache(o CacheOptions) *PathCache {
+	return &PathCache{opts: o, entries: list.New(), byKey: make(map[cacheKey]*list.Element)}
+}
+
+// Stats returns the counters of the cache.
+func (c *PathCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Entries = c.entries.Len()
+
+	return s
+}
+
+// Purge drops every entry.
+func (c *PathCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Init()
+	clear(c.byKey)
+}
+
+// InvalidateMap drops the entries of a compiled map, and so those of every map with the
+// same content. It hashes the map the first time it is asked for the map's entries.
+func (c *PathCache) InvalidateMap(cm *CompiledMap) {
+	c.invalidate(cm.fingerprint())
+}
+
+func (c *PathCache) invalidate(f mapFingerprint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for e := c.entries.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*cacheEntry); entry.key.fingerprint == f {
+			c.entries.Remove(e)
+			delete(c.byKey, entry.key)
+		}
+
+		e = next
+	}
+}
+
+// migrate moves the entries of a map to its patched version when keep says they are still
+// valid, and drops the others.
+func (c *PathCache) migrate(from mapFingerprint, to mapFingerprint, keep func(p *Path) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for e := c.entries.Front(); e != nil; {
+		next := e.Next()
+
+		if entry := e.Value.(*cacheEntry); entry.key.fingerprint == from {
+			delete(c.byKey, entry.key)
+			entry.key.fingerprint = to
+
+			// a search on the new version may have cached the same key already
+			if _, found := c.byKey[entry.key]; !found && keep(entry.path) {
+				c.byKey[entry.key] = e
+			} else {
+				c.entries.Remove(e)
+			}
+		}
+
+		e = next
+	}
+}
+
+// get returns a copy of the cached path, nil on a miss.
+func (c *PathCache) get(k cacheKey) *Path {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.byKey[k]
+	if ok && c.opts.TTL > 0 && time.Since(e.Value.(*cacheEntry).added) > c.opts.TTL {
+		c.entries.Remove(e)
+		delete(c.byKey, k)
+		c.stats.Evictions++
+		ok = false
+	}
+
+	if !ok {
+		c.stats.Misses++
+		return nil
+	}
+
+	c.stats.Hits++
+	c.entries.MoveToFront(e)
+
+	return clonePath(e.Value.(*cacheEntry).path)
+}
+
+// put stores a copy of the path.
+func (c *PathCache) put(k cacheKey, p *Path) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: k, path: clonePath(p), added: time.Now()}
+
+	if e, ok := c.byKey[k]; ok {
+		e.Value = entry
+		c.entries.MoveToFront(e)
+		return
+	}
+
+	c.byKey[k] = c.entries.PushFront(entry)
+
+	for c.opts.MaxEntries > 0 && c.entries.Len() > c.opts.MaxEntries {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.byKey, oldest.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// cacheable reports whether a search would give the same result again. Cancelled searches
+// depend on the caller's context, and region checks are cheaper than a lookup.
+func cacheable(stopReason string) bool {
+	return stopReason == StopReasonFound || stopReason == StopReasonNoPath || stopReason == StopReasonBudgetExceeded
+}
+
+// clonePath copies a path without the player's metadata: entries are shared by players
+// with the same search, and every lookup sets the metadata of the player asking.
+func clonePath(p *Path) *Path {
+	c := *p
+	c.Steps = cloneSteps(p.Steps)
+	c.Metadata = nil
+
+	if p.Stats != nil {
+		stats := *p.Stats
+		c.Stats = &stats
+	}
+
+	if p.Routes != nil {
+		c.Routes = make([]*Route, len(p.Routes))
+		for i, r := range p.Routes {
+			c.Routes[i] = &Route{Steps: cloneSteps(r.Steps), Cost: r.Cost}
+		}
+	}
+
+	return &c
+}
+
+func cloneSteps(steps []*Node) []*Node {
+	if steps == nil {
+		return nil
+	}
+
+	nodes := make([]Node, len(steps))
+	res := make([]*Node, len(steps))
+	for i, n := range steps {
+		nodes[i] = *n
+		res[i] = &nodes[i]
+	}
+
+	return res
+}
+
+// fingerprintOf hashes the cells and the extras of a prepared map.
+func fingerprintOf(m *model.GameMap) mapFingerprint {
+	h := sha256.New()
+
+	binary.Write(h, binary.LittleEndian, []int32{m.Width, m.Height, m.Depth(), m.TurnCost, m.MaxTurns, int32(m.Connectivity)})
+
+	var buf []byte
+	for _, level := range m.Levels {
+		for _, row := range level {
+			buf = buf[:0]
+			for _, c := range row {
+				buf = binary.LittleEndian.AppendUint32(buf, uint32(c))
+			}
+
+			h.Write(buf)
+		}
+	}
+
+	// the extras are small, their JSON is deterministic (map keys are sorted) and good enough
+	extras, _ := json.Marshal([]any{m.Wrap, m.CostLayers, m.LayerWeights, m.Portals, m.Exits, m.Connectors})
+	h.Write(extras)
+
+	var f mapFingerprint
+	h.Sum(f[:0])
+
+	return f
+}
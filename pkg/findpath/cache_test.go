@@ -0,0 +1,141 @@
+package findpath
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// cachedService returns a service with a new cache of the options.
+func cachedService(t *testing.T, o CacheOptions) (*FindPathService, *PathCache) {
+	t.Helper()
+
+	cache := NewPathCache(o)
+
+	service := newTestService(t)
+	service.SetPathCache(cache)
+
+	return service, cache
+}
+
+func wantCacheStats(t *testing.T, what string, c *PathCache, want CacheStats) {
+	t.Helper()
+
+	if got := c.Stats(); got != want {
+		t.Errorf("%s: cache stats %+v, want %+v", what, got, want)
+	}
+}
+
+func TestPathCacheHitsAndMisses(t *testing.T) {
+	service, cache := cachedService(t, CacheOptions{})
+	ctx := context.Background()
+
+	grid := &Grid{Width: 3, Height: 1, Cells: []int32{0, 0, 0}}
+	players := []*Player{{Target: Node{X: 2}}}
+
+	first, err := service.GetPathFromGrid(ctx, grid, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := service.GetPathFromGrid(ctx, grid, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first[0].Cached || !second[0].Cached {
+		t.Errorf("cached: first %v, second %v, want false and true", first[0].Cached, second[0].Cached)
+	}
+
+	if len(second[0].Steps) != 3 || *second[0].Stats != *first[0].Stats {
+		t.Errorf("cached path %v with stats %+v, want the first search's", second[0].Steps, second[0].Stats)
+	}
+
+	wantCacheStats(t, "same request", cache, CacheStats{Hits: 1, Misses: 1, Entries: 1})
+
+	// another target, another heading and another map are all new keys
+	for _, c := range []struct {
+		grid   *Grid
+		player *Player
+	}{
+		{grid, &Player{Target: Node{X: 1}}},
+		{grid, &Player{Target: Node{X: 2}, Heading: DirectionLeft}},
+		{&Grid{Width: 3, Height: 1, Cells: []int32{0, 0, 0}, Wrap: WrapHorizontal}, players[0]},
+	} {
+		paths, err := service.GetPathFromGrid(ctx, c.grid, []*Player{c.player})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if paths[0].Cached {
+			t.Errorf("%+v on %+v: cached, want a new search", *c.player, *c.grid)
+		}
+	}
+
+	wantCacheStats(t, "new keys", cache, CacheStats{Hits: 1, Misses: 4, Entries: 4})
+
+	// a modified copy of the grid has another fingerprint
+	blocked := &Grid{Width: 3, Height: 1, Cells: []int32{0, 1, 0}}
+	paths, err := service.GetPathFromGrid(ctx, blocked, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if paths[0].Cached || paths[0].Found {
+		t.Errorf("blocked grid: cached %v, found %v, want a new search finding nothing", paths[0].Cached, paths[0].Found)
+	}
+}
+
+func TestPathCacheLimits(t *testing.T) {
+	ctx := context.Background()
+	grid := &Grid{Width: 3, Height: 1, Cells: []int32{0, 0, 0}}
+	a, b := []*Player{{Target: Node{X: 1}}}, []*Player{{Target: Node{X: 2}}}
+
+	search := func(service *FindPathService, players []*Player) {
+		t.Helper()
+
+		if _, err := service.GetPathFromGrid(ctx, grid, players); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, cache := cachedService(t, CacheOptions{MaxEntries: 1})
+	search(service, a)
+	search(service, b)
+	search(service, a)
+	wantCacheStats(t, "max entries", cache, CacheStats{Misses: 3, Evictions: 2, Entries: 1})
+
+	service, cache = cachedService(t, CacheOptions{TTL: time.Millisecond})
+	search(service, a)
+	time.Sleep(2 * time.Millisecond)
+	search(service, a)
+	wantCacheStats(t, "ttl", cache, CacheStats{Misses: 2, Evictions: 1, Entries: 1})
+
+	cache.Purge()
+	wantCacheStats(t, "purged", cache, CacheStats{Misses: 2, Evictions: 1})
+}
+
+func TestPathCacheKeepsNoMetadata(t *testing.T) {
+	cache := NewPathCache(CacheOptions{})
+
+	meta := map[string]string{"team": "red"}
+	cache.put(cacheKey{}, &Path{Found: true, Stats: &Stats{}, Metadata: meta})
+
+	// the caller owns its map, changing it later doesn't reach the cache
+	meta["team"] = "blue"
+
+	if p := cache.byKey[cacheKey{}].Value.(*cacheEntry).path; p.Metadata != nil {
+		t.Errorf("cached path keeps metadata %v", p.Metadata)
+	}
+
+	got := cache.get(cacheKey{})
+	if got == nil || got.Metadata != nil {
+		t.Fatalf("lookup returned %+v, want a path without metadata", got)
+	}
+
+	// nor does changing the copy of a lookup
+	got.Metadata = map[string]string{"team": "green"}
+	if p := cache.get(cacheKey{}); p.Metadata != nil {
+		t.Errorf("second lookup has metadata %v", p.Metadata)
+	}
+}
@@ -0,0 +1,108 @@
+package findpath
+
+import (
+	"context"
+	"sync"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// CompiledMap is a grid prepared once for many queries: levels, cost layers, portals and the
+// steps every cell allows are built when compiling, so searches on it skip all of that.
+// It is safe for concurrent use.
+type CompiledMap struct {
+	gameMap *model.GameMap
+	version uint64 // bumped by every patch
+
+	fingerprintOnce sync.Once
+	fp              mapFingerprint
+}
+
+// CompileOptions selects the optional data built with a CompiledMap.
+type CompileOptions struct {
+	Components bool // label regions, so targets in another region are rejected without a search
+	Clearance  bool // measure the free square at every cell, see CompiledMap.Clearance
+}
+
+// Compile prepares a grid for repeated queries. The cells are copied, so later changes to
+// g don't affect the compiled map.
+func Compile(g *Grid, o CompileOptions) (*CompiledMap, error) {
+	cells := append([]int32(nil), g.Cells...)
+
+	gc := *g
+	gc.Cells = cells
+
+	gameMap, err := toGameMap(&gc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return compileGameMap(gameMap, o)
+}
+
+// CompileFile is like Compile for a JSON map file. The players of the file are ignored.
+func CompileFile(jsonFilename string, o CompileOptions) (*CompiledMap, error) {
+	gameMap, err := readGameMap(jsonFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	gameMap.Players = nil
+
+	return compileGameMap(gameMap, o)
+}
+
+func compileGameMap(gameMap *model.GameMap, o CompileOptions) (*CompiledMap, error) {
+	if err := prepareMap(gameMap); err != nil {
+		return nil, err
+	}
+
+	algorithms.PrepareMoves(gameMap)
+
+	if o.Components {
+		algorithms.LabelComponents(gameMap)
+	}
+
+	if o.Clearance {
+		algorithms.PrepareClearance(gameMap)
+	}
+
+	return &CompiledMap{gameMap: gameMap}, nil
+}
+
+// GetPathOnCompiledMap finds paths for every player on a compiled map, with the algorithm
+// and limits of the service.
+func (fps *FindPathService) GetPathOnCompiledMap(ctx context.Context, cm *CompiledMap, players []*Player) ([]*Path, error) {
+	return fps.searchPaths(ctx, cm.gameMap, toModelPlayers(players), cm.fingerprint, nil)
+}
+
+// fingerprint is computed on first use, only services with a cache need it.
+func (cm *CompiledMap) fingerprint() mapFingerprint {
+	cm.fingerprintOnce.Do(func() {
+		cm.fp = fingerprintOf(cm.gameMap)
+	})
+
+	return cm.fp
+}
+
+// Version counts the patches applied to the map since it was compiled.
+func (cm *CompiledMap) Version() uint64 {
+	return cm.version
+}
+
+// Components returns the regions of the map, nil unless it was compiled with them.
+func (cm *CompiledMap) Components() *Components {
+	if cm.gameMap.Components == nil {
+		return nil
+	}
+
+	return &Components{gameMap: cm.gameMap}
+}
+
+// Clearance returns the side of the largest walkable square with the cell as its top-left
+// corner: 0 for blocked cells, 1 for a cell with a blocked neighbour to its right or below.
+// It is always 0 unless the map was compiled with clearance.
+func (cm *CompiledMap) Clearance(n Node) int32 {
+	return algorithms.ClearanceAt(cm.gameMap, toModelNode(n))
+}
@@ -0,0 +1,56 @@
+package findpath
+
+import (
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Components labels the walkable cells of a grid by region. Cells of different regions can
+// never reach each other under the movement rules of the grid: connectivity, wrap,
+// connectors, portals and exits. Grids with other rules get their own labelling.
+type Components struct {
+	gameMap *model.GameMap
+}
+
+// ComponentsFromGrid labels the regions of a grid.
+func ComponentsFromGrid(g *Grid) (*Components, error) {
+	gameMap, err := toGameMap(g, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = prepareMap(gameMap); err != nil {
+		return nil, err
+	}
+
+	algorithms.LabelComponents(gameMap)
+
+	return &Components{gameMap: gameMap}, nil
+}
+
+// ID returns the region of a cell, -1 for blocked cells and cells outside the grid.
+func (c *Components) ID(n Node) int32 {
+	return algorithms.Component(c.gameMap, toModelNode(n))
+}
+
+// Size returns the number of cells in a region.
+func (c *Components) Size(id int32) int {
+	if id < 0 || int(id) >= len(c.gameMap.ComponentSizes) {
+		return 0
+	}
+
+	return int(c.gameMap.ComponentSizes[id])
+}
+
+// Sizes returns the number of cells of every region, indexed by region ID.
+func (c *Components) Sizes() []int32 {
+	return append([]int32(nil), c.gameMap.ComponentSizes...)
+}
+
+// Connected reports whether a path between the cells is possible. False means it surely
+// isn't, true still needs a search to confirm.
+func (c *Components) Connected(a Node, b Node) bool {
+	id := c.ID(a)
+
+	return id >= 0 && id == c.ID(b)
+}
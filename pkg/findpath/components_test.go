@@ -0,0 +1,130 @@
+package findpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unomns/findpath/internal/model"
+)
+
+// a corridor cut in two by a wall
+var splitGrid = &Grid{Width: 5, Height: 1, Cells: []int32{0, 0, 1, 0, 0}}
+
+func TestDifferentRegionsRejectedWithoutSearch(t *testing.T) {
+	cm, err := Compile(splitGrid, CompileOptions{Components: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := newTestService(t)
+	players := []*Player{
+		{Start: Node{X: 0}, Target: Node{X: 4}},
+		{Start: Node{X: 0}, Target: Node{X: 1}},
+	}
+
+	paths, err := service.GetPathOnCompiledMap(context.Background(), cm, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := paths[0]; p.StopReason != StopReasonDifferentRegion || p.Status != StatusNoPath || p.Stats.NodesExpanded != 0 {
+		t.Errorf("across the wall: %s, %s, %d expanded, want different_region, no_path and no search", p.StopReason, p.Status, p.Stats.NodesExpanded)
+	}
+
+	if p := paths[1]; p.StopReason != StopReasonFound {
+		t.Errorf("same region: %s, want found", p.StopReason)
+	}
+}
+
+func TestRegionsLabelledPerMap(t *testing.T) {
+	service := newTestService(t)
+	across := []*Player{{Start: Node{X: 0}, Target: Node{X: 4}}}
+
+	for round := range 2 {
+		paths, err := service.GetPathFromGrid(context.Background(), &Grid{Width: 5, Height: 1, Cells: []int32{0, 0, 1, 0, 0}}, across)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := paths[0]; p.StopReason != StopReasonDifferentRegion || p.Stats.NodesExpanded != 0 {
+			t.Errorf("round %d: got %s after %d expansions, want different_region and no search", round, p.StopReason, p.Stats.NodesExpanded)
+		}
+	}
+
+	// the second request took the labels of the first one
+	first, second := labelled(t, splitGrid), labelled(t, splitGrid)
+	if &first.Components[0] != &second.Components[0] {
+		t.Error("the same grid was labelled twice")
+	}
+
+	// the same cells are one region when the levels are voxels, two when they are floors
+	// without connectors
+	floors := &Grid{Width: 2, Height: 1, Depth: 2, Cells: make([]int32, 4)}
+	voxels := &Grid{Width: 2, Height: 1, Depth: 2, Cells: make([]int32, 4), Connectivity: Connectivity6}
+	up := []*Player{{Target: Node{Z: 1}}}
+
+	for _, c := range []struct {
+		g    *Grid
+		want string
+	}{{floors, StopReasonDifferentRegion}, {voxels, StopReasonFound}, {floors, StopReasonDifferentRegion}} {
+		paths, err := service.GetPathFromGrid(context.Background(), c.g, up)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := paths[0]; p.StopReason != c.want {
+			t.Errorf("connectivity %d: got %s, want %s", c.g.Connectivity, p.StopReason, c.want)
+		}
+	}
+
+	cm, err := Compile(splitGrid, CompileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := service.GetPathOnCompiledMap(context.Background(), cm, across)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := paths[0]; p.StopReason != StopReasonNoPath || p.Stats.NodesExpanded == 0 {
+		t.Errorf("compiled without regions: got %s after %d expansions, want no_path found by searching", p.StopReason, p.Stats.NodesExpanded)
+	}
+}
+
+// labelled returns the grid as a prepared map with the regions from the label cache.
+func labelled(t *testing.T, g *Grid) *model.GameMap {
+	t.Helper()
+
+	m, err := toGameMap(g, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = prepareMap(m); err != nil {
+		t.Fatal(err)
+	}
+
+	regionLabels.label(m, fingerprintOf(m))
+
+	return m
+}
+
+func TestComponentsFromGrid(t *testing.T) {
+	c, err := ComponentsFromGrid(splitGrid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Connected(Node{X: 0}, Node{X: 4}) || !c.Connected(Node{X: 3}, Node{X: 4}) {
+		t.Error("regions don't follow the wall")
+	}
+
+	if id := c.ID(Node{X: 2}); id != -1 {
+		t.Errorf("wall is in region %d, want -1", id)
+	}
+
+	if sizes := c.Sizes(); len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 2 {
+		t.Errorf("sizes = %v, want [2 2]", sizes)
+	}
+}
@@ -1,10 +1,12 @@
 package findpath
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"strconv"
 	"sync"
@@ -18,15 +20,33 @@ import (
 type FindPathService struct {
 	algo  string
 	debug bool
+
+	maxExpansions int
+	maxMemory     int64
+
+	kPaths     int
+	maxOverlap float64
+
+	heuristic       Heuristic
+	heuristicWeight float64
+	partialPaths    bool
+
+	pool  *WorkerPool
+	cache *PathCache
 }
 
 type Pathfinder interface {
 	GetPathFromFile(jsonFilename string) ([]*Path, error)
+	GetPathFromFileContext(ctx context.Context, jsonFilename string) ([]*Path, error)
 	GetPathFromFlatGrid(width int32, height int32, grid []int32, players []*Player) ([]*Path, error)
+	GetPathFromFlatGridContext(ctx context.Context, width int32, height int32, grid []int32, players []*Player) ([]*Path, error)
+	GetPathFromGrid(ctx context.Context, g *Grid, players []*Player) ([]*Path, error)
+	GetPathFromFlatVolume(width int32, height int32, depth int32, cells []int32, players []*Player) ([]*Path, error)
 }
 
 const (
-	AlgoAStar = "a-star"
+	AlgoAStar     = "a-star"
+	AlgoJumpPoint = "jps" // A* jumping over open space on 26-connected voxel grids, plain A* on other maps
 )
 
 func New(algo string, debug bool) (*FindPathService, error) {
@@ -40,35 +60,208 @@ func (fps *FindPathService) SetAStarSearchingAlgorithm() {
 	fps.algo = AlgoAStar
 }
 
+// SetMaxExpansions limits the number of nodes a single player search may expand. 0 means unlimited.
+func (fps *FindPathService) SetMaxExpansions(n int) {
+	fps.maxExpansions = n
+}
+
+// SetMaxMemory limits the approximate memory in bytes a single player search may hold. 0 means unlimited.
+func (fps *FindPathService) SetMaxMemory(bytes int64) {
+	fps.maxMemory = bytes
+}
+
+// SetKShortestPaths makes every search return up to k ranked alternative routes in Path.Routes,
+// found with Yen's algorithm. A route sharing more than maxOverlap (0..1) of its cells with a
+// better one is skipped; 0 disables the check. k <= 1 switches back to a single path.
+func (fps *FindPathService) SetKShortestPaths(k int, maxOverlap float64) {
+	fps.kPaths = k
+	fps.maxOverlap = maxOverlap
+}
+
+// SetHeuristic picks the heuristic of A* and scales it by weight. A weight above 1 finds paths
+// faster, but they may not be the cheapest; 0 means 1. Other algorithms ignore it.
+func (fps *FindPathService) SetHeuristic(h Heuristic, weight float64) {
+	fps.heuristic = h
+	fps.heuristicWeight = weight
+}
+
+// SetPartialPaths makes A* searches that fail return the path to the cell they got closest
+// to the target, with Path.Partial set. Targets in sealed-off regions are then searched too.
+// Other algorithms and maps with turn rules ignore it.
+func (fps *FindPathService) SetPartialPaths(on bool) {
+	fps.partialPaths = on
+}
+
+// SetWorkerPool runs the player searches of the service on a pool shared with other services.
+// Without one, the DefaultWorkerPool is used.
+func (fps *FindPathService) SetWorkerPool(p *WorkerPool) {
+	fps.pool = p
+}
+
+// SetPathCache makes the service reuse the paths of earlier searches with the same map,
+// algorithm, limits and player. Nil turns caching off. Without a compiled map, every
+// request hashes the whole grid to look its paths up.
+func (fps *FindPathService) SetPathCache(c *PathCache) {
+	fps.cache = c
+}
+
+func (fps *FindPathService) cacheKey(f mapFingerprint, p model.Player) cacheKey {
+	return cacheKey{
+		fingerprint:   f,
+		algo:          fps.algo,
+		maxExpansions: fps.maxExpansions,
+		maxMemory:     fps.maxMemory,
+		kPaths:        max(1, fps.kPaths),
+		maxOverlap:    fps.maxOverlap,
+
+		heuristic:       fps.heuristic,
+		heuristicWeight: fps.heuristicWeight,
+		partialPaths:    fps.partialPaths,
+
+		start:   p.Start,
+		target:  p.Target,
+		heading: p.Heading,
+	}
+}
+
+func (fps *FindPathService) searchOptions() (algorithms.Options, error) {
+	o := algorithms.Options{
+		MaxExpansions:   fps.maxExpansions,
+		MaxMemory:       fps.maxMemory,
+		HeuristicWeight: fps.heuristicWeight,
+		PartialPaths:    fps.partialPaths,
+	}
+
+	switch fps.heuristic {
+	case HeuristicDistance:
+		o.Heuristic = algorithms.HeuristicDistance
+	case HeuristicNone:
+		o.Heuristic = algorithms.HeuristicNone
+	default:
+		return o, fmt.Errorf("unknown heuristic %q", fps.heuristic)
+	}
+
+	if !(fps.heuristicWeight >= 0) || math.IsInf(fps.heuristicWeight, 0) {
+		return o, fmt.Errorf("invalid heuristic weight %v", fps.heuristicWeight)
+	}
+
+	return o, nil
+}
+
+func (fps *FindPathService) workerPool() *WorkerPool {
+	if fps.pool == nil {
+		return DefaultWorkerPool()
+	}
+
+	return fps.pool
+}
+
 func (fps *FindPathService) GetPathFromFlatGrid(width int32, height int32, grid []int32, players []*Player) ([]*Path, error) {
-	if len(grid) != int(width*height) {
-		return nil, errors.New("grid size does not match width × height")
+	return fps.GetPathFromFlatGridContext(context.Background(), width, height, grid, players)
+}
+
+// GetPathFromFlatGridContext is like GetPathFromFlatGrid but stops every search once ctx is done.
+func (fps *FindPathService) GetPathFromFlatGridContext(
+	ctx context.Context,
+	width int32,
+	height int32,
+	grid []int32,
+	players []*Player,
+) ([]*Path, error) {
+	return fps.GetPathFromGrid(ctx, &Grid{Width: width, Height: height, Cells: grid}, players)
+}
+
+// GetPathFromFlatVolume finds paths through a 3D occupancy grid with 26-connectivity. The cells go
+// level by level, use GetPathFromGrid with Grid.Connectivity for other neighbourhoods.
+func (fps *FindPathService) GetPathFromFlatVolume(width int32, height int32, depth int32, cells []int32, players []*Player) ([]*Path, error) {
+	return fps.GetPathFromGrid(context.Background(), &Grid{
+		Width:        width,
+		Height:       height,
+		Depth:        depth,
+		Cells:        cells,
+		Connectivity: Connectivity26,
+	}, players)
+}
+
+// GetPathFromGrid finds paths for every player on a flat grid with optional cost layers,
+// levels, portals and other extras. Bad dimensions fail the request with a FieldError, players
+// starting or ending off the map or on a blocked cell get an invalid Path.Status.
+func (fps *FindPathService) GetPathFromGrid(ctx context.Context, g *Grid, players []*Player) ([]*Path, error) {
+	gameMap, err := toGameMap(g, players)
+	if err != nil {
+		return nil, err
+	}
+
+	return fps.computePaths(ctx, gameMap, nil)
+}
+
+func toGameMap(g *Grid, players []*Player) (*model.GameMap, error) {
+	if err := validateGrid(g); err != nil {
+		return nil, err
 	}
 
+	width, height, grid := g.Width, g.Height, g.Cells
+	depth := max(1, g.Depth)
+
 	gameMap := model.GameMap{
-		Grid:    make([][]int32, height),
-		Players: make([]model.Player, len(players)),
-		Width:   width,
-		Height:  height,
-		Map:     make([]model.Node, len(grid)),
+		Levels:       make(model.Volume, depth),
+		Width:        width,
+		Height:       height,
+		CostLayers:   toModelLayers(g.CostLayers),
+		LayerWeights: g.LayerWeights,
+		TurnCost:     g.TurnCost,
+		MaxTurns:     g.MaxTurns,
+		Wrap:         model.Wrap(g.Wrap),
+		Portals:      toModelPortals(g.Portals),
+		Exits:        toModelExits(g.Exits),
+		Connectors:   toModelConnectors(g.Connectors),
+		Connectivity: model.Connectivity(g.Connectivity),
 	}
 
-	var y int32
-	for y = 0; y < height; y++ {
-		gameMap.Grid[y] = grid[y*width : (y+1)*width]
+	for z := range depth {
+		level := grid[z*width*height : (z+1)*width*height]
+
+		gameMap.Levels[z] = make([][]int32, height)
+		for y := range height {
+			gameMap.Levels[z][y] = level[y*width : (y+1)*width]
+		}
 	}
 
+	gameMap.Players = toModelPlayers(players)
+
+	return &gameMap, nil
+}
+
+func toModelPlayers(players []*Player) []model.Player {
+	res := make([]model.Player, len(players))
 	for i, p := range players {
-		gameMap.Players[i] = model.Player{
-			Start:  model.Node{Y: p.Start.Y, X: p.Start.X},
-			Target: model.Node{Y: p.Target.Y, X: p.Target.X},
+		res[i] = model.Player{
+			ID:       p.ID,
+			Start:    toModelNode(p.Start),
+			Target:   toModelNode(p.Target),
+			Heading:  model.Direction(p.Heading),
+			Metadata: p.Metadata,
 		}
 	}
 
-	return fps.computePaths(&gameMap)
+	return res
 }
 
 func (fps *FindPathService) GetPathFromFile(jsonFilename string) ([]*Path, error) {
+	return fps.GetPathFromFileContext(context.Background(), jsonFilename)
+}
+
+// GetPathFromFileContext is like GetPathFromFile but stops every search once ctx is done.
+func (fps *FindPathService) GetPathFromFileContext(ctx context.Context, jsonFilename string) ([]*Path, error) {
+	gameMap, err := readGameMap(jsonFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	return fps.computePaths(ctx, gameMap, nil)
+}
+
+func readGameMap(jsonFilename string) (*model.GameMap, error) {
 	data, err := os.ReadFile(jsonFilename)
 	if err != nil {
 		return nil, fmt.Errorf("read file error: %v", err)
@@ -80,71 +273,325 @@ func (fps *FindPathService) GetPathFromFile(jsonFilename string) ([]*Path, error
 		return nil, fmt.Errorf("file has invalid format: %v", err)
 	}
 
-	return fps.computePaths(&gameMap)
+	return &gameMap, nil
 }
 
-func (fps *FindPathService) computePaths(gameMap *model.GameMap) ([]*Path, error) {
-	paths := make([]*Path, len(gameMap.Players))
+func (fps *FindPathService) computePaths(ctx context.Context, gameMap *model.GameMap, emit func(i int, p *Path)) ([]*Path, error) {
+	if err := prepareMap(gameMap); err != nil {
+		return nil, err
+	}
+
+	fingerprint := sync.OnceValue(func() mapFingerprint {
+		return fingerprintOf(gameMap)
+	})
+
+	// searches for partial paths don't stop at region borders
+	if !fps.partialPaths {
+		regionLabels.label(gameMap, fingerprint())
+	}
+
+	return fps.searchPaths(ctx, gameMap, gameMap.Players, fingerprint, emit)
+}
 
-	var algo algorithms.PathFinder
-	var err error
+// searchPaths runs one search per player on a prepared map, which it doesn't modify. The
+// fingerprint of the map is only asked for when the service has a cache. A non-nil emit gets
+// every path as soon as it is known, one call at a time.
+func (fps *FindPathService) searchPaths(
+	ctx context.Context,
+	gameMap *model.GameMap,
+	players []model.Player,
+	fingerprint func() mapFingerprint,
+	emit func(i int, p *Path),
+) ([]*Path, error) {
+	paths := make([]*Path, len(players))
 
-	if algo, err = factory.NewPathFinder(fps.algo, fps.debug); err != nil {
+	algo, err := factory.NewPathFinder(fps.algo, fps.debug)
+	if err != nil {
 		return nil, err
 	}
 
 	if fps.debug {
 		log.Printf("Algo choosen: '%s'\n", algo.Name())
 		log.Println("--------Map Grid---------")
-		for y := 0; y < int(gameMap.Height); y++ {
-			fmt.Printf("[%d]", y)
-			for x := 0; x < int(gameMap.Width); x++ {
-				fmt.Printf(" %d", gameMap.Grid[y][x])
+		for z, level := range gameMap.Levels {
+			if len(gameMap.Levels) > 1 {
+				log.Printf("level %d:\n", z)
+			}
+
+			for y := 0; y < int(gameMap.Height); y++ {
+				fmt.Printf("[%d]", y)
+				for x := 0; x < int(gameMap.Width); x++ {
+					fmt.Printf(" %d", level[y][x])
+				}
+				fmt.Println()
 			}
-			fmt.Println()
 		}
 		log.Println("-------------------------")
 	}
-	pathFindingService := app.NewPathFindingService(algo)
+	opts, err := fps.searchOptions()
+	if err != nil {
+		return nil, err
+	}
 
-	var wg sync.WaitGroup
+	pathFindingService := app.NewPathFindingService(algo, opts)
+
+	find := func(p *model.Player) *algorithms.Result {
+		if fps.kPaths > 1 {
+			return pathFindingService.FindRoutes(ctx, *gameMap, p, fps.kPaths, fps.maxOverlap)
+		}
 
-	for i, p := range gameMap.Players {
-		p.ID = i + 1
-		paths[i] = &Path{PlayerID: strconv.Itoa(i), Found: false}
+		return pathFindingService.FindPath(ctx, *gameMap, p)
+	}
 
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+	var keys []cacheKey
 
-			path := pathFindingService.FindPath(*gameMap, &p)
+	if fps.cache != nil {
+		keys = make([]cacheKey, len(players))
+		f := fingerprint()
 
-			if path == nil {
-				if fps.debug {
-					log.Printf("Player #%d Target not detected!\n", p.ID)
-				}
+		for i, p := range players {
+			keys[i] = fps.cacheKey(f, p)
+		}
+	}
 
-				return
-			}
+	var emitting sync.Mutex
 
-			if fps.debug {
-				log.Printf("Player #%d Path found [start:%v][end:%v]:\n", p.ID, p.Start, p.Target)
+	// Cached players and players in another region are tasks too, so nothing is emitted
+	// before the pool has taken the request.
+	err = fps.workerPool().run(ctx, len(players), func(i int) {
+		if keys != nil {
+			if cached := fps.cache.get(keys[i]); cached != nil {
+				// Players with the same search share the entry, the ID and metadata are
+				// always the requester's.
+				cached.PlayerID = playerID(players[i], i)
+				cached.Metadata = players[i].Metadata
+				cached.Cached = true
+				paths[i] = cached
 			}
+		}
 
-			paths[i].Found = true
-			paths[i].Steps = make([]*Node, len(path))
+		if paths[i] == nil {
+			paths[i] = fps.searchPlayer(gameMap, players[i], i, find)
 
-			for k, n := range path {
-				if fps.debug {
-					log.Printf("[%d] %v\n", k, *n)
-				}
-				paths[i].Steps[k] = &Node{Y: n.Y, X: n.X}
+			if keys != nil && cacheable(paths[i].StopReason) {
+				fps.cache.put(keys[i], paths[i])
 			}
-			log.Println()
-		}()
+		}
+
+		if emit != nil {
+			emitting.Lock()
+			defer emitting.Unlock()
+
+			emit(i, paths[i])
+		}
+	})
+	if err != nil && !errors.Is(err, ctx.Err()) {
+		return nil, err
 	}
 
-	wg.Wait()
+	// players dropped from the queue when ctx ended were never searched
+	for i, p := range paths {
+		if p != nil {
+			continue
+		}
+
+		paths[i] = (&Path{PlayerID: playerID(players[i], i), StopReason: StopReasonCancelled, Stats: &Stats{}, Metadata: players[i].Metadata}).settle()
+		if emit != nil {
+			emit(i, paths[i])
+		}
+	}
 
 	return paths, nil
 }
+
+// searchPlayer finds the path of the player at index i of the request with find, unless the
+// player is invalid or the target is in another region and no partial path is wanted.
+func (fps *FindPathService) searchPlayer(
+	gameMap *model.GameMap,
+	p model.Player,
+	i int,
+	find func(p *model.Player) *algorithms.Result,
+) *Path {
+	p.ID = playerID(p, i)
+	res := &Path{PlayerID: p.ID, Found: false, Metadata: p.Metadata}
+
+	if status, err := validatePlayer(gameMap, i, p); err != nil {
+		res.StopReason = StopReasonInvalidPlayer
+		res.Status = status
+		res.Message = joinFieldErrors(err)
+		res.Stats = &Stats{}
+		res.err = err
+
+		if fps.debug {
+			log.Printf("Player %s is invalid: %v\n", p.ID, err)
+		}
+
+		return res
+	}
+
+	// A partial path needs the search, even when the target can't be reached.
+	if !fps.partialPaths && algorithms.DifferentRegions(gameMap, p.Start, p.Target) {
+		res.StopReason = algorithms.ReasonDifferentRegion.String()
+		res.Stats = &Stats{}
+
+		if fps.debug {
+			log.Printf("Player %s Target not detected: %s\n", p.ID, algorithms.ReasonDifferentRegion)
+		}
+
+		return res.settle()
+	}
+
+	found := find(&p)
+	res.StopReason = found.Reason.String()
+	res.Stats = toStats(found.Stats)
+
+	if found.Reason != algorithms.ReasonFound {
+		if fps.debug {
+			log.Printf("Player %s Target not detected: %s\n", p.ID, found.Reason)
+		}
+
+		if len(found.Path) > 0 {
+			res.Partial = true
+			res.Steps = toSteps(found.Path)
+		}
+
+		return res.settle()
+	}
+
+	if fps.debug {
+		log.Printf("Player %s Path found [start:%v][end:%v]:\n", p.ID, p.Start, p.Target)
+	}
+
+	res.Found = true
+	res.Steps = make([]*Node, len(found.Path))
+
+	for k, n := range found.Path {
+		if fps.debug {
+			log.Printf("[%d] %v\n", k, *n)
+		}
+		res.Steps[k] = fromModelNode(n)
+	}
+	log.Println()
+
+	for _, r := range found.Routes {
+		res.Routes = append(res.Routes, &Route{Steps: toSteps(r.Path), Cost: r.Cost})
+	}
+
+	return res.settle()
+}
+
+// playerID returns the ID the caller gave the player at index i of the request, or the index.
+func playerID(p model.Player, i int) string {
+	if p.ID == "" {
+		return strconv.Itoa(i)
+	}
+
+	return p.ID
+}
+
+// settle sets the status of a searched path from its stop reason.
+func (p *Path) settle() *Path {
+	switch p.StopReason {
+	case StopReasonFound:
+		p.Status = StatusOK
+	case StopReasonNoPath:
+		p.Status, p.Message = StatusNoPath, "no path between start and target"
+	case StopReasonDifferentRegion:
+		p.Status, p.Message = StatusNoPath, "start and target are in regions sealed off from each other"
+	case StopReasonCancelled:
+		p.Status, p.Message = StatusCancelled, "search cancelled before it finished"
+	case StopReasonBudgetExceeded:
+		p.Status, p.Message = StatusBudgetExceeded, "search stopped by its expansion or memory limit"
+	}
+
+	return p
+}
+
+func toSteps(path []*model.Node) []*Node {
+	steps := make([]*Node, len(path))
+	for k, n := range path {
+		steps[k] = fromModelNode(n)
+	}
+
+	return steps
+}
+
+func toModelLayers(layers []*CostLayer) []model.CostLayer {
+	res := make([]model.CostLayer, len(layers))
+
+	for i, l := range layers {
+		res[i] = model.CostLayer{Name: l.Name, Dense: l.Dense}
+
+		for _, c := range l.Cells {
+			res[i].Cells = append(res[i].Cells, model.CellCost{Y: c.Y, X: c.X, Z: c.Z, Cost: c.Cost})
+		}
+
+		for _, s := range l.Sources {
+			res[i].Sources = append(res[i].Sources, model.InfluenceSource{
+				Y:        s.Y,
+				X:        s.X,
+				Z:        s.Z,
+				Strength: s.Strength,
+				Radius:   s.Radius,
+				Falloff:  model.Falloff(s.Falloff),
+			})
+		}
+	}
+
+	return res
+}
+
+func toModelPortals(portals []*Portal) []model.Portal {
+	res := make([]model.Portal, len(portals))
+	for i, p := range portals {
+		res[i] = model.Portal{
+			From: toModelNode(p.From),
+			To:   toModelNode(p.To),
+			Cost: p.Cost,
+		}
+	}
+
+	return res
+}
+
+func toModelExits(exits []*CellExits) []model.CellExits {
+	res := make([]model.CellExits, len(exits))
+	for i, e := range exits {
+		res[i] = model.CellExits{Y: e.Y, X: e.X, Z: e.Z, Allow: make([]model.Direction, len(e.Allow))}
+		for k, d := range e.Allow {
+			res[i].Allow[k] = model.Direction(d)
+		}
+	}
+
+	return res
+}
+
+func toModelConnectors(connectors []*Connector) []model.Connector {
+	res := make([]model.Connector, len(connectors))
+	for i, c := range connectors {
+		res[i] = model.Connector{Kind: c.Kind, Cost: c.Cost, Cells: make([]model.Node, len(c.Cells))}
+		for k, n := range c.Cells {
+			res[i].Cells[k] = toModelNode(n)
+		}
+	}
+
+	return res
+}
+
+func toModelNode(n Node) model.Node {
+	return model.Node{Y: n.Y, X: n.X, Z: n.Z}
+}
+
+func fromModelNode(n *model.Node) *Node {
+	return &Node{Y: n.Y, X: n.X, Z: n.Z}
+}
+
+func toStats(s algorithms.Stats) *Stats {
+	return &Stats{
+		Cost:           s.Cost,
+		NodesExpanded:  s.Expanded,
+		NodesGenerated: s.Generated,
+		PeakOpen:       s.PeakOpen,
+		Duration:       s.Duration,
+	}
+}
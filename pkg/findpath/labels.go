@@ -0,0 +1,81 @@
+package findpath
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// maxLabelledCells bounds the region labels kept for maps that aren't compiled, 64 MiB.
+const maxLabelledCells = 1 << 24
+
+// movementProfile is what decides which neighbours a cell has, besides the cells, portals,
+// exits and connectors hashed into the fingerprint.
+type movementProfile struct {
+	connectivity model.Connectivity // 0 for flat grids, 6, 18 or 26 for voxel grids
+	wrap         model.Wrap
+}
+
+type labelKey struct {
+	profile     movementProfile
+	fingerprint mapFingerprint
+}
+
+type labels struct {
+	key        labelKey
+	components []int32
+	sizes      []int32
+}
+
+// labelCache keeps the regions of recently searched maps, so a request repeating a map
+// rejects targets in another region without labelling it again. Services are often made per
+// request, so one cache is shared by all of them.
+type labelCache struct {
+	mu      sync.Mutex
+	entries *list.List // of *labels, most recently used first
+	byKey   map[labelKey]*list.Element
+	cells   int
+}
+
+var regionLabels = &labelCache{entries: list.New(), byKey: make(map[labelKey]*list.Element)}
+
+// label sets the regions of a prepared map, from the cache when the same map was labelled
+// before. The cached labels are shared and never modified.
+func (c *labelCache) label(m *model.GameMap, f mapFingerprint) {
+	k := labelKey{profile: movementProfile{connectivity: m.Connectivity, wrap: m.Wrap}, fingerprint: f}
+
+	c.mu.Lock()
+	if e, ok := c.byKey[k]; ok {
+		c.entries.MoveToFront(e)
+		l := e.Value.(*labels)
+		m.Components, m.ComponentSizes = l.components, l.sizes
+		c.mu.Unlock()
+
+		return
+	}
+	c.mu.Unlock()
+
+	// concurrent requests for a new map may both label it, the second one keeps its own
+	algorithms.LabelComponents(m)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.byKey[k]; ok || len(m.Components) > maxLabelledCells {
+		return
+	}
+
+	c.byKey[k] = c.entries.PushFront(&labels{key: k, components: m.Components, sizes: m.ComponentSizes})
+	c.cells += len(m.Components)
+
+	for c.cells > maxLabelledCells {
+		oldest := c.entries.Back()
+		l := oldest.Value.(*labels)
+
+		c.entries.Remove(oldest)
+		delete(c.byKey, l.key)
+		c.cells -= len(l.components)
+	}
+}
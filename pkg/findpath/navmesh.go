@@ -0,0 +1,224 @@
+package findpath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// NavMesh is a prepared set of convex polygons, safe to reuse across queries.
+type NavMesh struct {
+	mesh model.NavMesh
+}
+
+type NavPlayer struct {
+	ID       string            `json:"id,omitempty"` // returned as NavPath.PlayerID, the index of the player when empty
+	Start    Point             `json:"start"`
+	Target   Point             `json:"target"`
+	Metadata map[string]string `json:"metadata,omitempty"` // opaque to the service, returned as NavPath.Metadata
+}
+
+type NavPath struct {
+	PlayerID   string            `json:"player_id"`
+	Found      bool              `json:"found"`
+	Points     []*Point          `json:"points"` // corners of the smoothed path, start and target included
+	Length     float64           `json:"length"`
+	StopReason string            `json:"stop_reason"`
+	Stats      *Stats            `json:"stats"`
+	Metadata   map[string]string `json:"metadata,omitempty"` // of the player, as given
+}
+
+// NewNavMesh builds a mesh from convex polygons given as indices into vertices. Polygons
+// sharing both vertices of an edge are connected through it.
+func NewNavMesh(vertices []Point, polygons [][]int32) (*NavMesh, error) {
+	nm := &NavMesh{mesh: model.NavMesh{
+		Vertices: make([]model.Point, len(vertices)),
+		Polygons: make([][]int32, len(polygons)),
+	}}
+
+	for i, v := range vertices {
+		nm.mesh.Vertices[i] = model.Point{X: v.X, Y: v.Y}
+	}
+
+	for i, p := range polygons {
+		nm.mesh.Polygons[i] = append([]int32(nil), p...)
+	}
+
+	if err := algorithms.PrepareNavMesh(&nm.mesh); err != nil {
+		return nil, fmt.Errorf("invalid navmesh: %w", err)
+	}
+
+	return nm, nil
+}
+
+// GetPathFromNavMeshFile loads a mesh with its players from a JSON file and finds their paths.
+func (fps *FindPathService) GetPathFromNavMeshFile(ctx context.Context, jsonFilename string) ([]*NavPath, error) {
+	nm, err := LoadNavMesh(jsonFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]*NavPlayer, len(nm.mesh.Players))
+	for i, p := range nm.mesh.Players {
+		players[i] = &NavPlayer{
+			ID:       p.ID,
+			Start:    Point{X: p.Start.X, Y: p.Start.Y},
+			Target:   Point{X: p.Target.X, Y: p.Target.Y},
+			Metadata: p.Metadata,
+		}
+	}
+
+	return fps.GetPathOnNavMesh(ctx, nm, players)
+}
+
+// GetPathOnNavMesh finds a smoothed path for every player. The searching algorithm of the
+// service is not used, navmeshes are always searched with A* and the funnel algorithm.
+func (fps *FindPathService) GetPathOnNavMesh(ctx context.Context, nm *NavMesh, players []*NavPlayer) ([]*NavPath, error) {
+	opts := algorithms.Options{MaxExpansions: fps.maxExpansions, MaxMemory: fps.maxMemory}
+
+	return fps.findNavPaths(ctx, players, func(p *model.NavPlayer) *algorithms.NavResult {
+		return algorithms.FindOnNavMesh(ctx, &nm.mesh, p, opts)
+	})
+}
+
+// findNavPaths runs one float-coordinate search per player on the worker pool.
+func (fps *FindPathService) findNavPaths(
+	ctx context.Context,
+	players []*NavPlayer,
+	find func(p *model.NavPlayer) *algorithms.NavResult,
+) ([]*NavPath, error) {
+	paths := make([]*NavPath, len(players))
+
+	navPlayerID := func(i int) string {
+		if players[i].ID == "" {
+			return strconv.Itoa(i)
+		}
+
+		return players[i].ID
+	}
+
+	err := fps.workerPool().run(ctx, len(players), func(i int) {
+		p := players[i]
+		id := navPlayerID(i)
+
+		paths[i] = &NavPath{PlayerID: id, Metadata: p.Metadata}
+
+		res := find(&model.NavPlayer{
+			ID:       id,
+			Start:    model.Point{X: p.Start.X, Y: p.Start.Y},
+			Target:   model.Point{X: p.Target.X, Y: p.Target.Y},
+			Metadata: p.Metadata,
+		})
+
+		paths[i].StopReason = res.Reason.String()
+		paths[i].Stats = toStats(res.Stats)
+
+		if res.Reason != algorithms.ReasonFound {
+			return
+		}
+
+		paths[i].Found = true
+		paths[i].Length = res.Length
+		paths[i].Points = make([]*Point, len(res.Path))
+		for k, pt := range res.Path {
+			paths[i].Points[k] = &Point{X: pt.X, Y: pt.Y}
+		}
+	})
+	if err != nil && !errors.Is(err, ctx.Err()) {
+		return nil, err
+	}
+
+	// players dropped from the queue when ctx ended were never searched
+	for i, p := range paths {
+		if p == nil {
+			paths[i] = &NavPath{PlayerID: navPlayerID(i), StopReason: StopReasonCancelled, Stats: &Stats{}, Metadata: players[i].Metadata}
+		}
+	}
+
+	return paths, nil
+}
+
+// NavMeshFromGrid converts the walkable cells of one grid level into a navmesh of maximal
+// rectangles. Cell [y x] covers the square from (x, y) to (x+1, y+1), see CellCenter.
+// Only walkability is carried over: cost layers, portals, exits and wrapping are ignored.
+func NavMeshFromGrid(g *Grid, level int32) (*NavMesh, error) {
+	gameMap, err := toGameMap(g, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateNavMesh(gameMap, level)
+}
+
+// NavMeshFromFile is like NavMeshFromGrid for a JSON map file.
+func NavMeshFromFile(jsonFilename string, level int32) (*NavMesh, error) {
+	gameMap, err := readGameMap(jsonFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateNavMesh(gameMap, level)
+}
+
+func generateNavMesh(gameMap *model.GameMap, level int32) (*NavMesh, error) {
+	if err := prepareMap(gameMap); err != nil {
+		return nil, err
+	}
+
+	mesh, err := algorithms.GenerateNavMesh(gameMap, level)
+	if err != nil {
+		return nil, fmt.Errorf("navmesh generation failed: %w", err)
+	}
+
+	return &NavMesh{mesh: *mesh}, nil
+}
+
+// LoadNavMesh reads a mesh in the JSON format written by NavMesh.MarshalJSON. Portals are
+// rebuilt from the shared edges, and players, if any, are only used by GetPathFromNavMeshFile.
+func LoadNavMesh(jsonFilename string) (*NavMesh, error) {
+	data, err := os.ReadFile(jsonFilename)
+	if err != nil {
+		return nil, fmt.Errorf("read file error: %v", err)
+	}
+
+	var nm NavMesh
+	if err = json.Unmarshal(data, &nm.mesh); err != nil {
+		return nil, fmt.Errorf("file has invalid format: %v", err)
+	}
+
+	if err = algorithms.PrepareNavMesh(&nm.mesh); err != nil {
+		return nil, fmt.Errorf("invalid navmesh: %w", err)
+	}
+
+	return &nm, nil
+}
+
+// MarshalJSON exports the vertices, polygons and portals of the mesh.
+func (nm *NavMesh) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Vertices []model.Point     `json:"vertices"`
+		Polygons [][]int32         `json:"polygons"`
+		Portals  []model.NavPortal `json:"portals"`
+	}{nm.mesh.Vertices, nm.mesh.Polygons, nm.mesh.Portals})
+}
+
+// PolygonCount returns the number of polygons in the mesh.
+func (nm *NavMesh) PolygonCount() int {
+	return len(nm.mesh.Polygons)
+}
+
+// CellCenter returns the navmesh point in the middle of a grid cell.
+func CellCenter(n Node) Point {
+	return Point{X: float64(n.X) + 0.5, Y: float64(n.Y) + 0.5}
+}
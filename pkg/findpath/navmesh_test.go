@@ -0,0 +1,191 @@
+package findpath
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func newTestService(t *testing.T) *FindPathService {
+	t.Helper()
+
+	service, err := New(AlgoAStar, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service
+}
+
+func TestNavMeshExample(t *testing.T) {
+	paths, err := newTestService(t).GetPathFromNavMeshFile(context.Background(), "../../navmesh.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mesh is a U around the hole between y = 2 and y = 4 left of x = 4. The first player
+	// goes round the hole and the funnel pulls the path tight to its corners, the second one
+	// walks straight up the right arm.
+	want := []struct {
+		points []Point
+		length float64
+	}{
+		{[]Point{{X: 0.5, Y: 1}, {X: 4, Y: 2}, {X: 4, Y: 4}, {X: 0.5, Y: 5}}, 2 + 2*math.Hypot(3.5, 1)},
+		{[]Point{{X: 5, Y: 1}, {X: 5, Y: 5}}, 4},
+	}
+
+	if len(paths) != len(want) {
+		t.Fatalf("%d paths, want %d", len(paths), len(want))
+	}
+
+	for i, p := range paths {
+		if !p.Found {
+			t.Errorf("player %d: %s, want found", i, p.StopReason)
+			continue
+		}
+
+		var got []Point
+		for _, pt := range p.Points {
+			got = append(got, *pt)
+		}
+
+		if !slices.Equal(got, want[i].points) {
+			t.Errorf("player %d: points %v, want %v", i, got, want[i].points)
+		}
+
+		if math.Abs(p.Length-want[i].length) > 1e-9 {
+			t.Errorf("player %d: length %f, want %f", i, p.Length, want[i].length)
+		}
+	}
+}
+
+func TestNavMeshOutsidePolygons(t *testing.T) {
+	// the U of the example without its players
+	nm, err := NewNavMesh(
+		[]Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}, {X: 6, Y: 0}, {X: 6, Y: 2}, {X: 6, Y: 6}, {X: 4, Y: 6}, {X: 0, Y: 6}, {X: 0, Y: 4}, {X: 4, Y: 4}},
+		[][]int32{{0, 1, 2, 3}, {1, 4, 5, 2}, {2, 5, 6, 7, 10}, {9, 10, 7, 8}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// inside the hole of the U
+	paths, err := newTestService(t).GetPathOnNavMesh(context.Background(), nm, []*NavPlayer{{Start: Point{X: 1, Y: 3}, Target: Point{X: 5, Y: 5}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := paths[0]; p.Found || p.StopReason != StopReasonNoPath {
+		t.Errorf("got %s, want no_path", p.StopReason)
+	}
+}
+
+// uGrid is the U of the example as a 6×6 grid, the hole covering the cells left of x = 4 on
+// rows 2 and 3.
+func uGrid() *Grid {
+	g := &Grid{Width: 6, Height: 6, Cells: make([]int32, 36)}
+	for y := 2; y < 4; y++ {
+		for x := range 4 {
+			g.Cells[y*6+x] = 1
+		}
+	}
+
+	return g
+}
+
+func TestNavMeshFromGridRoundTrip(t *testing.T) {
+	nm, err := NavMeshFromGrid(uGrid(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nm.PolygonCount() != 3 {
+		t.Errorf("%d polygons, want the top row, the right arm and the bottom row", nm.PolygonCount())
+	}
+
+	data, err := json.Marshal(nm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "mesh.json")
+	if err = os.WriteFile(file, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadNavMesh(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the portals are rebuilt on loading, the same ones as exported
+	if !bytes.Equal(again, data) {
+		t.Errorf("exported\n%s\nloaded and exported again\n%s", data, again)
+	}
+
+	if _, err = NavMeshFromGrid(uGrid(), 1); err == nil {
+		t.Error("level 1 of a single level grid: no error")
+	}
+}
+
+// The path over the generated mesh goes round the hole the way the grid path does, pulled
+// tight to the corners of the hole.
+func TestNavMeshFromGridMatchesGridPath(t *testing.T) {
+	service := newTestService(t)
+	start, target := Node{}, Node{Y: 5}
+
+	grid, err := service.GetPathFromGrid(context.Background(), uGrid(), []*Player{{Start: start, Target: target}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !grid[0].Found || grid[0].Stats.Cost != 13 {
+		t.Fatalf("grid path found %v with cost %d, want round the hole with cost 13", grid[0].Found, grid[0].Stats.Cost)
+	}
+
+	nm, err := NavMeshFromGrid(uGrid(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mesh, err := service.GetPathOnNavMesh(context.Background(), nm, []*NavPlayer{{Start: CellCenter(start), Target: CellCenter(target)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := mesh[0]
+	if !p.Found {
+		t.Fatalf("got %s, want found", p.StopReason)
+	}
+
+	var got []Point
+	for _, pt := range p.Points {
+		got = append(got, *pt)
+	}
+
+	want := []Point{CellCenter(start), {X: 4, Y: 2}, {X: 4, Y: 4}, CellCenter(target)}
+	if !slices.Equal(got, want) {
+		t.Errorf("points %v, want %v", got, want)
+	}
+
+	if length := 2 + 2*math.Hypot(3.5, 1.5); math.Abs(p.Length-length) > 1e-9 {
+		t.Errorf("length %f, want %f", p.Length, length)
+	}
+
+	// both go through the right arm, the only way round
+	for _, s := range grid[0].Steps {
+		if s.Y >= 2 && s.Y < 4 && s.X < 4 {
+			t.Errorf("grid path crosses the hole at %v", *s)
+		}
+	}
+}
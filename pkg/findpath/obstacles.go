@@ -0,0 +1,77 @@
+package findpath
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// VisibilityRequest asks for paths through open continuous space around polygonal obstacles.
+type VisibilityRequest struct {
+	Obstacles [][]Point    `json:"obstacles"` // simple polygons, paths may touch their corners and edges
+	Players   []*NavPlayer `json:"players"`
+}
+
+// ObstacleMap is a prepared visibility graph between obstacle corners, safe to reuse across queries.
+type ObstacleMap struct {
+	om model.ObstacleMap
+}
+
+// NewObstacleMap builds the visibility graph of the obstacles. Building it takes
+// O(n³) time in the number of corners, so reuse the map when the obstacles don't change.
+func NewObstacleMap(obstacles [][]Point) (*ObstacleMap, error) {
+	om := &ObstacleMap{om: model.ObstacleMap{Obstacles: make([][]model.Point, len(obstacles))}}
+
+	for i, o := range obstacles {
+		om.om.Obstacles[i] = make([]model.Point, len(o))
+		for k, v := range o {
+			om.om.Obstacles[i][k] = model.Point{X: v.X, Y: v.Y}
+		}
+	}
+
+	if err := algorithms.PrepareObstacles(&om.om); err != nil {
+		return nil, fmt.Errorf("invalid obstacles: %w", err)
+	}
+
+	return om, nil
+}
+
+// GetPathAroundObstacles builds the visibility graph of the request and finds the shortest
+// path for every player. Like navmeshes, it is always searched with A*.
+func (fps *FindPathService) GetPathAroundObstacles(ctx context.Context, req *VisibilityRequest) ([]*NavPath, error) {
+	om, err := NewObstacleMap(req.Obstacles)
+	if err != nil {
+		return nil, err
+	}
+
+	return fps.GetPathOnObstacleMap(ctx, om, req.Players)
+}
+
+// GetPathAroundObstaclesFile reads a VisibilityRequest from a JSON file and finds its paths.
+func (fps *FindPathService) GetPathAroundObstaclesFile(ctx context.Context, jsonFilename string) ([]*NavPath, error) {
+	data, err := os.ReadFile(jsonFilename)
+	if err != nil {
+		return nil, fmt.Errorf("read file error: %v", err)
+	}
+
+	var req VisibilityRequest
+	if err = json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("file has invalid format: %v", err)
+	}
+
+	return fps.GetPathAroundObstacles(ctx, &req)
+}
+
+// GetPathOnObstacleMap finds the shortest path for every player on a prepared obstacle map.
+// Players starting or ending inside an obstacle get no path.
+func (fps *FindPathService) GetPathOnObstacleMap(ctx context.Context, om *ObstacleMap, players []*NavPlayer) ([]*NavPath, error) {
+	opts := algorithms.Options{MaxExpansions: fps.maxExpansions, MaxMemory: fps.maxMemory}
+
+	return fps.findNavPaths(ctx, players, func(p *model.NavPlayer) *algorithms.NavResult {
+		return algorithms.FindAroundObstacles(ctx, &om.om, p, opts)
+	})
+}
@@ -0,0 +1,87 @@
+package findpath
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestObstaclesExample(t *testing.T) {
+	paths, err := newTestService(t).GetPathAroundObstaclesFile(context.Background(), "../../obstacles.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first player passes below the box and over the tip of the triangle, the second one
+	// goes round the left end of the bar to get into the notch, the third one starts inside
+	// the box.
+	want := []struct {
+		reason string
+		points []Point
+		length float64
+	}{
+		{StopReasonFound, []Point{{X: 0, Y: 3}, {X: 2, Y: 5}, {X: 7.5, Y: 6}, {X: 10, Y: 4}}, math.Hypot(2, 2) + math.Hypot(5.5, 1) + math.Hypot(2.5, 2)},
+		{StopReasonFound, []Point{{X: 7, Y: 10}, {X: 5, Y: 8}, {X: 5, Y: 7}, {X: 7, Y: 6.5}}, math.Hypot(2, 2) + 1 + math.Hypot(2, 0.5)},
+		{StopReasonNoPath, nil, 0},
+	}
+
+	if len(paths) != len(want) {
+		t.Fatalf("%d paths, want %d", len(paths), len(want))
+	}
+
+	for i, p := range paths {
+		if p.StopReason != want[i].reason || p.Found != (want[i].reason == StopReasonFound) {
+			t.Errorf("player %d: found %v with %s, want %s", i, p.Found, p.StopReason, want[i].reason)
+			continue
+		}
+
+		var got []Point
+		for _, pt := range p.Points {
+			got = append(got, *pt)
+		}
+
+		if !slices.Equal(got, want[i].points) {
+			t.Errorf("player %d: points %v, want %v", i, got, want[i].points)
+		}
+
+		if math.Abs(p.Length-want[i].length) > 1e-9 {
+			t.Errorf("player %d: length %f, want %f", i, p.Length, want[i].length)
+		}
+	}
+}
+
+func TestObstacleMapReuse(t *testing.T) {
+	if _, err := NewObstacleMap([][]Point{{{X: 0, Y: 0}, {X: 1, Y: 1}}}); err == nil {
+		t.Error("an obstacle with 2 vertices: no error")
+	}
+
+	// two squares meeting at a corner, paths may squeeze through it
+	om, err := NewObstacleMap([][]Point{
+		{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+		{{X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 4}, {X: 2, Y: 4}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := newTestService(t)
+
+	for range 2 {
+		paths, err := service.GetPathOnObstacleMap(context.Background(), om, []*NavPlayer{
+			{ID: "squeeze", Start: Point{X: 0, Y: 4}, Target: Point{X: 4, Y: 0}},
+			{ID: "inside", Start: Point{X: 1, Y: 1}, Target: Point{X: 4, Y: 0}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := paths[0]; !p.Found || len(p.Points) != 2 || math.Abs(p.Length-math.Hypot(4, 4)) > 1e-9 {
+			t.Errorf("squeeze: found %v with %d points and length %f, want a straight line", p.Found, len(p.Points), p.Length)
+		}
+
+		if p := paths[1]; p.PlayerID != "inside" || p.StopReason != StopReasonNoPath {
+			t.Errorf("inside: %s got %s, want no_path", p.PlayerID, p.StopReason)
+		}
+	}
+}
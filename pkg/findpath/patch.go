@@ -0,0 +1,204 @@
+package findpath
+
+import (
+	"fmt"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// MapPatch is a batch of cell changes, applied all together or not at all. Cells are set
+// after the rectangles are filled.
+type MapPatch struct {
+	Cells []*CellPatch `json:"cells"`
+	Rects []*RectPatch `json:"rects"`
+}
+
+// CellPatch sets one cell, 0 is walkable.
+type CellPatch struct {
+	Node
+	Value int32 `json:"value"`
+}
+
+// RectPatch fills the box between two corners, both included.
+type RectPatch struct {
+	From  Node  `json:"from"`
+	To    Node  `json:"to"`
+	Value int32 `json:"value"`
+}
+
+// patchEffect tells what a patch changed, so that only the derived data it affects is rebuilt.
+type patchEffect struct {
+	gameMap   *model.GameMap // the patched map
+	changed   []model.Node   // cells that became walkable or blocked
+	unblocked []model.Node   // the changed cells that became walkable
+}
+
+// Patch returns a copy of the map with the patch applied and the version bumped. The
+// original map is left unchanged, searches running on it are not affected.
+func (cm *CompiledMap) Patch(p *MapPatch) (*CompiledMap, error) {
+	next, _, err := cm.patch(p)
+
+	return next, err
+}
+
+func (cm *CompiledMap) patch(p *MapPatch) (*CompiledMap, patchEffect, error) {
+	var effect patchEffect
+
+	old := cm.gameMap
+	m := *old
+	m.Levels = copyVolume(old.Levels)
+	m.Grid = m.Levels[0]
+
+	set := func(n model.Node, v int32) error {
+		if n.X < 0 || n.Y < 0 || n.Z < 0 || n.X >= m.Width || n.Y >= m.Height || n.Z >= m.Depth() {
+			return fmt.Errorf("patch: cell %v is out of the map", n)
+		}
+
+		was := old.Levels[n.Z][n.Y][n.X]
+		m.Levels[n.Z][n.Y][n.X] = v
+
+		if (was == 0) != (v == 0) {
+			effect.changed = append(effect.changed, n)
+			if v == 0 {
+				effect.unblocked = append(effect.unblocked, n)
+			}
+		}
+
+		return nil
+	}
+
+	for _, r := range p.Rects {
+		if r.From.X > r.To.X || r.From.Y > r.To.Y || r.From.Z > r.To.Z {
+			return nil, effect, fmt.Errorf("patch: rectangle %v - %v has its corners swapped", r.From, r.To)
+		}
+
+		for z := r.From.Z; z <= r.To.Z; z++ {
+			for y := r.From.Y; y <= r.To.Y; y++ {
+				for x := r.From.X; x <= r.To.X; x++ {
+					if err := set(model.Node{Y: y, X: x, Z: z}, r.Value); err != nil {
+						return nil, effect, err
+					}
+				}
+			}
+		}
+	}
+
+	for _, c := range p.Cells {
+		if err := set(toModelNode(c.Node), c.Value); err != nil {
+			return nil, effect, err
+		}
+	}
+
+	if len(effect.changed) > 0 {
+		if err := algorithms.PreparePortals(&m); err != nil {
+			return nil, effect, fmt.Errorf("patch: %w", err)
+		}
+
+		m.Moves = append([]uint32(nil), old.Moves...)
+		algorithms.UpdateMoves(&m, effect.changed)
+
+		if old.Components != nil {
+			algorithms.UpdateComponents(&m, effect.changed)
+		}
+
+		if old.Clearance != nil {
+			m.Clearance = append([]int32(nil), old.Clearance...)
+			algorithms.UpdateClearance(&m, effect.changed)
+		}
+	}
+
+	effect.gameMap = &m
+
+	return &CompiledMap{gameMap: &m, version: cm.version + 1}, effect, nil
+}
+
+// stillValid reports whether a path found before the patch is still a cheapest one. Blocking
+// cells only removes options, so paths avoiding them stay the best ones, and targets out of
+// reach stay so. Cells made walkable may open cheaper ways, unless every way through them
+// costs at least as much as the path. A partial path stays valid under the rules for
+// blocking only.
+func (e patchEffect) stillValid(p *Path) bool {
+	if len(e.changed) == 0 {
+		return true
+	}
+
+	switch p.StopReason {
+	case StopReasonNoPath:
+		if len(e.unblocked) > 0 {
+			return false
+		}
+
+		if !p.Partial {
+			return true
+		}
+	case StopReasonFound:
+	default:
+		return false
+	}
+
+	blocked := make(map[Node]bool, len(e.changed))
+	for _, n := range e.changed {
+		blocked[*fromModelNode(&n)] = true
+	}
+
+	crosses := func(steps []*Node) bool {
+		for _, n := range steps {
+			if blocked[*n] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if crosses(p.Steps) {
+		return false
+	}
+
+	cost := p.Stats.Cost
+	for _, r := range p.Routes {
+		if crosses(r.Steps) {
+			return false
+		}
+
+		cost = max(cost, r.Cost)
+	}
+
+	if len(e.unblocked) == 0 {
+		return true
+	}
+
+	start, target := toModelNode(*p.Steps[0]), toModelNode(*p.Steps[len(p.Steps)-1])
+	for _, n := range e.unblocked {
+		if algorithms.CostBound(e.gameMap, start, n)+algorithms.CostBound(e.gameMap, n, target) < cost {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyVolume(v model.Volume) model.Volume {
+	if len(v) == 0 {
+		return v
+	}
+
+	height, width := len(v[0]), 0
+	if height > 0 {
+		width = len(v[0][0])
+	}
+
+	cells := make([]int32, len(v)*height*width)
+	res := make(model.Volume, len(v))
+
+	for z, level := range v {
+		res[z] = make([][]int32, height)
+		for y, row := range level {
+			res[z][y] = cells[(z*height+y)*width : (z*height+y+1)*width : (z*height+y+1)*width]
+			copy(res[z][y], row)
+		}
+	}
+
+	return res
+}
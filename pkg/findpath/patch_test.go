@@ -0,0 +1,171 @@
+package findpath
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPatchInvalidatesAffectedPaths(t *testing.T) {
+	service, cache := cachedService(t, CacheOptions{})
+	registry := NewMapRegistry(RegistryOptions{Cache: cache})
+
+	cm, err := Compile(&Grid{Width: 3, Height: 3, Cells: make([]int32, 9)}, CompileOptions{Components: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := registry.Add(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// along the top row
+	players := []*Player{{Target: Node{X: 2}}}
+
+	search := func() *Path {
+		t.Helper()
+
+		cm, err := registry.Get(info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		paths, err := service.GetPathOnCompiledMap(context.Background(), cm, players)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return paths[0]
+	}
+
+	patch := func(p *MapPatch, version uint64) {
+		t.Helper()
+
+		info, err := registry.Patch(info.ID, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Version != version {
+			t.Errorf("version %d after the patch, want %d", info.Version, version)
+		}
+	}
+
+	steps := []struct {
+		name   string
+		patch  *MapPatch
+		cached bool
+		reason string
+		cost   int32
+	}{
+		{"first search", nil, false, StopReasonFound, 2},
+		{"same search", nil, true, StopReasonFound, 2},
+		{"blocked off the path", &MapPatch{Cells: []*CellPatch{{Node: Node{Y: 2, X: 0}, Value: 1}}}, true, StopReasonFound, 2},
+		{"blocked on the path", &MapPatch{Cells: []*CellPatch{{Node: Node{Y: 0, X: 1}, Value: 1}}}, false, StopReasonFound, 4},
+		{"unblocked too far away", &MapPatch{Cells: []*CellPatch{{Node: Node{Y: 2, X: 0}}}}, true, StopReasonFound, 4},
+		{"unblocked on a shorter way", &MapPatch{Cells: []*CellPatch{{Node: Node{Y: 0, X: 1}}}}, false, StopReasonFound, 2},
+		{"column blocked", &MapPatch{Rects: []*RectPatch{{From: Node{X: 1}, To: Node{Y: 2, X: 1}, Value: 1}}}, false, StopReasonDifferentRegion, 0},
+	}
+
+	var version uint64
+	for _, s := range steps {
+		if s.patch != nil {
+			version++
+			patch(s.patch, version)
+		}
+
+		p := search()
+		if p.Cached != s.cached || p.StopReason != s.reason || p.Stats.Cost != s.cost {
+			t.Errorf("%s: cached %v, %s with cost %d, want cached %v, %s with cost %d",
+				s.name, p.Cached, p.StopReason, p.Stats.Cost, s.cached, s.reason, s.cost)
+		}
+	}
+
+	if cm.Version() != 0 || cm.gameMap.Grid[0][1] != 0 {
+		t.Errorf("the uploaded map changed: version %d", cm.Version())
+	}
+}
+
+func TestPatchOutOfTheMap(t *testing.T) {
+	cm, err := Compile(&Grid{Width: 2, Height: 1, Cells: []int32{0, 0}}, CompileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is applied when one change is bad
+	_, err = cm.Patch(&MapPatch{Cells: []*CellPatch{{Node: Node{X: 0}, Value: 1}, {Node: Node{X: 2}, Value: 1}}})
+	if err == nil {
+		t.Fatal("patched a cell out of the map")
+	}
+
+	if cm.gameMap.Grid[0][0] != 0 {
+		t.Error("a failed patch changed the map")
+	}
+}
+
+func TestRegistryDropsCachedPaths(t *testing.T) {
+	service, cache := cachedService(t, CacheOptions{})
+	registry := NewMapRegistry(RegistryOptions{MaxMaps: 2, Cache: cache})
+
+	// two uploads of the same cells share their paths
+	var ids []string
+	for range 2 {
+		cm, err := Compile(&Grid{Width: 3, Height: 1, Cells: make([]int32, 3)}, CompileOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := registry.Add(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, info.ID)
+	}
+
+	search := func(id string) *Path {
+		t.Helper()
+
+		cm, err := registry.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		paths, err := service.GetPathOnCompiledMap(context.Background(), cm, []*Player{{Target: Node{X: 2}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return paths[0]
+	}
+
+	if search(ids[0]).Cached || !search(ids[1]).Cached {
+		t.Error("the second upload doesn't share the paths of the first one")
+	}
+
+	if err := registry.Delete(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCacheStats(t, "after deleting an upload", cache, CacheStats{Hits: 1, Misses: 1})
+
+	if search(ids[1]).Cached {
+		t.Error("the other upload kept the paths of the deleted one")
+	}
+
+	// evicted maps lose their paths as well
+	for range 2 {
+		cm, err := Compile(&Grid{Width: 2, Height: 1, Cells: make([]int32, 2)}, CompileOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := registry.Add(cm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s := cache.Stats(); s.Entries != 0 {
+		t.Errorf("%d cached paths after evicting the map, want none", s.Entries)
+	}
+}
@@ -0,0 +1,55 @@
+package findpath
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func TestPlayerIDsAndMetadataEchoed(t *testing.T) {
+	service, _ := cachedService(t, CacheOptions{})
+
+	// a corridor cut in two by a wall
+	cm, err := Compile(&Grid{Width: 5, Height: 1, Cells: []int32{0, 0, 1, 0, 0}}, CompileOptions{Components: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	players := func(round string) []*Player {
+		return []*Player{
+			{ID: "scout-" + round, Target: Node{X: 1}, Metadata: map[string]string{"round": round}},
+			{Target: Node{X: 1}},
+			{ID: "tank-" + round, Start: Node{X: 2}, Target: Node{X: 1}, Metadata: map[string]string{"team": "red"}},
+			{ID: "walled-" + round, Target: Node{X: 4}, Metadata: map[string]string{"round": round}},
+		}
+	}
+
+	// the second round takes the searches from the cache, the IDs and metadata are its own
+	for _, round := range []string{"1", "2"} {
+		ps := players(round)
+
+		paths, err := service.GetPathOnCompiledMap(context.Background(), cm, ps)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !paths[0].Found || !paths[1].Found || paths[2].Status != StatusInvalidStart || paths[3].StopReason != StopReasonDifferentRegion {
+			t.Fatalf("round %s: unexpected results %+v %+v %+v %+v", round, *paths[0], *paths[1], *paths[2], *paths[3])
+		}
+
+		if round == "2" && !(paths[0].Cached && paths[1].Cached) {
+			t.Errorf("round 2: cached %v and %v, want both from the cache", paths[0].Cached, paths[1].Cached)
+		}
+
+		for i, p := range paths {
+			id := ps[i].ID
+			if id == "" {
+				id = "1" // the index
+			}
+
+			if p.PlayerID != id || !maps.Equal(p.Metadata, ps[i].Metadata) {
+				t.Errorf("round %s, player %d: id %q with metadata %v, want %q with %v", round, i, p.PlayerID, p.Metadata, id, ps[i].Metadata)
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package findpath
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrPoolSaturated is returned when a worker pool already holds as many requests as
+	// its queue depth allows. The request can be retried later.
+	ErrPoolSaturated = errors.New("worker pool is saturated")
+	ErrPoolClosed    = errors.New("worker pool is closed")
+)
+
+// PoolOptions configures a WorkerPool. Zero values pick the defaults.
+type PoolOptions struct {
+	Workers    int // searches running at once, GOMAXPROCS by default
+	QueueDepth int // requests waiting for or using the workers, more are rejected; 0 - no limit
+
+	// QueueDepth counts requests whatever their number of players, it bounds the requests
+	// held by the pool, not the searches left. Workers limits the searches running at once.
+}
+
+// WorkerPool runs the player searches of many requests on a fixed number of goroutines.
+// Workers take players from the waiting requests in turn, so a request with thousands of
+// players doesn't hold back the small ones queued after it. One pool can be shared by
+// any number of services.
+type WorkerPool struct {
+	opts PoolOptions
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	jobs     []*poolJob // requests with players left to start, taken round-robin
+	next     int
+	requests int // requests admitted and not finished yet
+	closed   bool
+	workers  sync.WaitGroup
+}
+
+// poolJob is one request: n searches run by calling task with every index.
+type poolJob struct {
+	n       int
+	started int
+	dropped bool // the request's context ended before every task started
+	task    func(i int)
+	done    sync.WaitGroup
+}
+
+var (
+	defaultPool     *WorkerPool
+	defaultPoolOnce sync.Once
+)
+
+// DefaultWorkerPool returns the pool used by services without one of their own. It has
+// GOMAXPROCS workers and no queue limit.
+func DefaultWorkerPool() *WorkerPool {
+	defaultPoolOnce.Do(func() {
+		defaultPool = NewWorkerPool(PoolOptions{})
+	})
+
+	return defaultPool
+}
+
+// NewWorkerPool starts the workers of a pool. Close stops them.
+func NewWorkerPool(o PoolOptions) *WorkerPool {
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	p := &WorkerPool{opts: o}
+	p.cond = sync.NewCond(&p.mu)
+
+	p.workers.Add(o.Workers)
+	for range o.Workers {
+		go p.work()
+	}
+
+	return p
+}
+
+// Close lets the workers finish the queued searches, then stops them. Requests made after
+// Close fail with ErrPoolClosed.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.workers.Wait()
+}
+
+// run calls task for every index in 0..n-1 on the workers and waits for all of them. Once
+// ctx is done, the tasks not started yet are dropped and run returns ctx.Err() as soon as
+// the started ones are finished.
+func (p *WorkerPool) run(ctx context.Context, n int, task func(i int)) error {
+	if n == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+
+	if p.opts.QueueDepth > 0 && p.requests >= p.opts.QueueDepth {
+		p.mu.Unlock()
+		return ErrPoolSaturated
+	}
+
+	job := &poolJob{n: n, task: task}
+	job.done.Add(n)
+
+	p.requests++
+	p.jobs = append(p.jobs, job)
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() {
+		p.drop(job)
+	})
+
+	job.done.Wait()
+	stop()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests--
+
+	if job.dropped {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// drop takes the tasks of the job that haven't started off the queue.
+func (p *WorkerPool) drop(job *poolJob) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := slices.Index(p.jobs, job)
+	if k < 0 {
+		return
+	}
+
+	p.jobs = slices.Delete(p.jobs, k, k+1)
+	if k < p.next {
+		p.next--
+	}
+
+	job.dropped = true
+	job.done.Add(job.started - job.n)
+}
+
+func (p *WorkerPool) work() {
+	defer p.workers.Done()
+
+	for {
+		p.mu.Lock()
+		for len(p.jobs) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+
+		if len(p.jobs) == 0 {
+			p.mu.Unlock()
+			return
+		}
+
+		p.next %= len(p.jobs)
+		job := p.jobs[p.next]
+		i := job.started
+		job.started++
+
+		if job.started == job.n {
+			p.jobs = slices.Delete(p.jobs, p.next, p.next+1)
+		} else {
+			p.next++
+		}
+
+		p.mu.Unlock()
+
+		job.task(i)
+		job.done.Done()
+	}
+}
@@ -0,0 +1,125 @@
+package findpath
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// hold keeps a request with one player on the pool until the returned func is called.
+func hold(t *testing.T, p *WorkerPool) (release func()) {
+	t.Helper()
+
+	started, done := make(chan struct{}), make(chan struct{})
+	finished := make(chan error)
+
+	go func() {
+		finished <- p.run(context.Background(), 1, func(int) {
+			close(started)
+			<-done
+		})
+	}()
+
+	<-started
+
+	return func() {
+		close(done)
+		if err := <-finished; err != nil {
+			t.Errorf("held request: %v", err)
+		}
+	}
+}
+
+func TestWorkerPoolSaturated(t *testing.T) {
+	pool := NewWorkerPool(PoolOptions{Workers: 1, QueueDepth: 1})
+	defer pool.Close()
+
+	service := newTestService(t)
+	service.SetWorkerPool(pool)
+
+	grid := &Grid{Width: 2, Height: 1, Cells: []int32{0, 0}}
+	players := []*Player{{Target: Node{X: 1}}}
+
+	release := hold(t, pool)
+
+	if _, err := service.GetPathFromGrid(context.Background(), grid, players); !errors.Is(err, ErrPoolSaturated) {
+		t.Errorf("with the queue full: %v, want ErrPoolSaturated", err)
+	}
+
+	release()
+
+	paths, err := service.GetPathFromGrid(context.Background(), grid, players)
+	if err != nil {
+		t.Fatalf("after the queue drained: %v", err)
+	}
+
+	if !paths[0].Found {
+		t.Errorf("after the queue drained: %s, want found", paths[0].StopReason)
+	}
+}
+
+func TestWorkerPoolClosed(t *testing.T) {
+	pool := NewWorkerPool(PoolOptions{Workers: 1})
+	pool.Close()
+
+	if err := pool.run(context.Background(), 1, func(int) {}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("got %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestWorkerPoolDropsCancelledRequests(t *testing.T) {
+	pool := NewWorkerPool(PoolOptions{Workers: 1, QueueDepth: 2})
+	defer pool.Close()
+
+	service := newTestService(t)
+	service.SetWorkerPool(pool)
+
+	grid := &Grid{Width: 2, Height: 1, Cells: []int32{0, 0}}
+	players := []*Player{{Target: Node{X: 1}}, {ID: "second", Target: Node{X: 1}}}
+
+	release := hold(t, pool)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan []*Path)
+
+	go func() {
+		paths, err := service.GetPathFromGrid(ctx, grid, players)
+		if err != nil {
+			t.Errorf("cancelled request: %v", err)
+		}
+
+		finished <- paths
+	}()
+
+	cancel()
+
+	// the only worker is still held, the players are taken off the queue without a search
+	select {
+	case paths := <-finished:
+		for i, p := range paths {
+			if id := []string{"0", "second"}[i]; p.Status != StatusCancelled || p.StopReason != StopReasonCancelled || p.PlayerID != id {
+				t.Errorf("player %d: %s with %s, %s, want %s cancelled", i, p.PlayerID, p.Status, p.StopReason, id)
+			}
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the cancelled request waited for the worker")
+	}
+
+	release()
+
+	pool.mu.Lock()
+	if pool.requests != 0 || len(pool.jobs) != 0 {
+		t.Errorf("%d requests with %d jobs left in the pool, want none", pool.requests, len(pool.jobs))
+	}
+	pool.mu.Unlock()
+
+	paths, err := service.GetPathFromGrid(context.Background(), grid, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !paths[0].Found || !paths[1].Found {
+		t.Errorf("after the drop: %s and %s, want found", paths[0].StopReason, paths[1].StopReason)
+	}
+}
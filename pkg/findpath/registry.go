@@ -0,0 +1,242 @@
+package findpath
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrMapNotFound = errors.New("map not found")
+	ErrMapTooLarge = errors.New("map is larger than the registry")
+)
+
+// RegistryOptions limits a MapRegistry. Zero values mean unlimited.
+type RegistryOptions struct {
+	MaxMaps  int
+	MaxCells int64 // cells of all maps together
+
+	// Cache, if set, loses the paths of maps leaving the registry and keeps those a patch
+	// can't have changed. Paths are keyed by map content, so maps with the same cells share
+	// them: deleting or patching one of them drops the paths of the others too, which only
+	// costs new searches.
+	Cache *PathCache
+}
+
+// MapInfo describes a registered map.
+type MapInfo struct {
+	ID       string    `json:"id"`
+	Width    int32     `json:"width"`
+	Height   int32     `json:"height"`
+	Depth    int32     `json:"depth"`
+	Cells    int64     `json:"cells"`
+	Version  uint64    `json:"version"` // number of patches applied
+	Added    time.Time `json:"added"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// MapRegistry keeps compiled maps by ID, so that they can be uploaded once and queried many
+// times. When a new map doesn't fit the limits, the least recently used maps are evicted.
+type MapRegistry struct {
+	opts RegistryOptions
+
+	mu    sync.Mutex
+	maps  map[string]*registeredMap
+	cells int64
+}
+
+type registeredMap struct {
+	cm   *CompiledMap
+	info MapInfo
+
+	patching sync.Mutex // patches of one map are applied one after another
+}
+
+func NewMapRegistry(o RegistryOptions) *MapRegistry {
+	return &MapRegistry{opts: o, maps: make(map[string]*registeredMap)}
+}
+
+// Add registers a compiled map under a new ID.
+func (r *MapRegistry) Add(cm *CompiledMap) (MapInfo, error) {
+	m := cm.gameMap
+	cells := int64(m.Width) * int64(m.Height) * int64(m.Depth())
+
+	if r.opts.MaxCells > 0 && cells > r.opts.MaxCells {
+		return MapInfo{}, fmt.Errorf("%w: %d cells, at most %d", ErrMapTooLarge, cells, r.opts.MaxCells)
+	}
+
+	id, err := newMapID()
+	if err != nil {
+		return MapInfo{}, err
+	}
+
+	now := time.Now()
+	rm := &registeredMap{cm: cm, info: MapInfo{
+		ID:       id,
+		Width:    m.Width,
+		Height:   m.Height,
+		Depth:    m.Depth(),
+		Cells:    cells,
+		Added:    now,
+		LastUsed: now,
+	}}
+
+	var evicted []*CompiledMap
+
+	r.mu.Lock()
+	for (r.opts.MaxMaps > 0 && len(r.maps) >= r.opts.MaxMaps) || (r.opts.MaxCells > 0 && r.cells+cells > r.opts.MaxCells) {
+		evicted = append(evicted, r.removeLocked(r.leastRecentlyUsedLocked()))
+	}
+
+	r.maps[id] = rm
+	r.cells += cells
+	info := rm.info
+	r.mu.Unlock()
+
+	r.invalidate(evicted...)
+
+	return info, nil
+}
+
+// Get returns a registered map and marks it as used.
+func (r *MapRegistry) Get(id string) (*CompiledMap, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rm, ok := r.maps[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMapNotFound, id)
+	}
+
+	rm.info.LastUsed = time.Now()
+
+	return rm.cm, nil
+}
+
+// Patch applies a patch to a registered map. Searches started before keep using the previous
+// version, later ones get the new one; cached paths the patch can't have changed are kept.
+func (r *MapRegistry) Patch(id string, p *MapPatch) (MapInfo, error) {
+	r.mu.Lock()
+	rm, ok := r.maps[id]
+	r.mu.Unlock()
+
+	if !ok {
+		return MapInfo{}, fmt.Errorf("%w: %q", ErrMapNotFound, id)
+	}
+
+	rm.patching.Lock()
+	defer rm.patching.Unlock()
+
+	r.mu.Lock()
+	cm := rm.cm
+	r.mu.Unlock()
+
+	next, effect, err := cm.patch(p)
+	if err != nil {
+		return MapInfo{}, err
+	}
+
+	r.mu.Lock()
+	if r.maps[id] != rm {
+		r.mu.Unlock()
+		return MapInfo{}, fmt.Errorf("%w: %q", ErrMapNotFound, id)
+	}
+
+	rm.cm = next
+	rm.info.Version = next.version
+	rm.info.LastUsed = time.Now()
+	info := rm.info
+	r.mu.Unlock()
+
+	if r.opts.Cache != nil {
+		r.opts.Cache.migrate(cm.fingerprint(), next.fingerprint(), effect.stillValid)
+	}
+
+	return info, nil
+}
+
+// Delete removes a map from the registry.
+func (r *MapRegistry) Delete(id string) error {
+	r.mu.Lock()
+	if _, ok := r.maps[id]; !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %q", ErrMapNotFound, id)
+	}
+
+	cm := r.removeLocked(id)
+	r.mu.Unlock()
+
+	r.invalidate(cm)
+
+	return nil
+}
+
+// List describes the registered maps, most recently added first.
+func (r *MapRegistry) List() []MapInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]MapInfo, 0, len(r.maps))
+	for _, rm := range r.maps {
+		res = append(res, rm.info)
+	}
+
+	slices.SortFunc(res, func(a, b MapInfo) int {
+		if c := b.Added.Compare(a.Added); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return res
+}
+
+func (r *MapRegistry) leastRecentlyUsedLocked() string {
+	var id string
+	var last time.Time
+
+	for k, rm := range r.maps {
+		if id == "" || rm.info.LastUsed.Before(last) {
+			id, last = k, rm.info.LastUsed
+		}
+	}
+
+	return id
+}
+
+// removeLocked takes a map out of the registry and returns it, its cached paths are left
+// to invalidate once r.mu is released: hashing a large map takes a while.
+func (r *MapRegistry) removeLocked(id string) *CompiledMap {
+	rm := r.maps[id]
+
+	delete(r.maps, id)
+	r.cells -= rm.info.Cells
+
+	return rm.cm
+}
+
+// invalidate drops the cached paths of maps that left the registry.
+func (r *MapRegistry) invalidate(maps ...*CompiledMap) {
+	if r.opts.Cache == nil {
+		return
+	}
+
+	for _, cm := range maps {
+		r.opts.Cache.InvalidateMap(cm)
+	}
+}
+
+func newMapID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("can't generate map id: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,101 @@
+package findpath
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPlayerStatuses(t *testing.T) {
+	// 0 0 1 0
+	// 0 0 1 0
+	grid := &Grid{Width: 4, Height: 2, Cells: []int32{0, 0, 1, 0, 0, 0, 1, 0}}
+
+	cases := []struct {
+		name   string
+		player *Player
+		status Status
+		err    error // wrapped by Path.Err
+		fields []string
+	}{
+		{"found", &Player{Target: Node{Y: 1, X: 1}}, StatusOK, nil, nil},
+		{"walled off", &Player{Target: Node{X: 3}}, StatusNoPath, nil, nil},
+		{"start off the map", &Player{Start: Node{Y: -1}, Target: Node{X: 1}}, StatusInvalidStart, ErrOutOfBounds, []string{"players[2].start"}},
+		{"blocked start", &Player{Start: Node{X: 2}, Target: Node{X: 1}}, StatusInvalidStart, ErrBlockedStart, []string{"players[3].start"}},
+		{"bad heading", &Player{Target: Node{X: 1}, Heading: "north"}, StatusInvalidStart, ErrInvalidHeading, []string{"players[4].heading"}},
+		{"blocked target", &Player{Target: Node{Y: 1, X: 2}}, StatusInvalidTarget, ErrBlockedTarget, []string{"players[5].target"}},
+		{"both ends bad", &Player{Start: Node{X: 9}, Target: Node{X: 2}}, StatusInvalidStart, ErrBlockedTarget, []string{"players[6].start", "players[6].target"}},
+	}
+
+	players := make([]*Player, len(cases))
+	for i, c := range cases {
+		players[i] = c.player
+	}
+
+	paths, err := newTestService(t).GetPathFromGrid(context.Background(), grid, players)
+	if err != nil {
+		t.Fatalf("bad players failed the request: %v", err)
+	}
+
+	for i, c := range cases {
+		p := paths[i]
+		if p.Status != c.status || p.Found != (c.status == StatusOK) || (p.Message == "") != (c.status == StatusOK) {
+			t.Errorf("%s: status %s, found %v, message %q, want %s", c.name, p.Status, p.Found, p.Message, c.status)
+		}
+
+		if c.err == nil {
+			if p.Err() != nil {
+				t.Errorf("%s: error %v", c.name, p.Err())
+			}
+
+			continue
+		}
+
+		if !errors.Is(p.Err(), c.err) {
+			t.Errorf("%s: error %v, want %v", c.name, p.Err(), c.err)
+		}
+
+		var fields []string
+		for _, f := range FieldErrors(p.Err()) {
+			fields = append(fields, f.Field)
+
+			if f.Player != i {
+				t.Errorf("%s: field error %v of player %d", c.name, f, f.Player)
+			}
+		}
+
+		if !slices.Equal(fields, c.fields) {
+			t.Errorf("%s: fields %v, want %v", c.name, fields, c.fields)
+		}
+	}
+}
+
+func TestStoppedSearchStatuses(t *testing.T) {
+	grid := &Grid{Width: 20, Height: 20, Cells: make([]int32, 400)}
+	players := []*Player{{Target: Node{Y: 19, X: 19}}}
+
+	service := newTestService(t)
+	service.SetMaxExpansions(5)
+
+	paths, err := service.GetPathFromGrid(context.Background(), grid, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := paths[0]; p.Status != StatusBudgetExceeded || p.StopReason != StopReasonBudgetExceeded {
+		t.Errorf("max expansions: %s, %s, want budget_exceeded", p.Status, p.StopReason)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	paths, err = newTestService(t).GetPathFromGrid(ctx, grid, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := paths[0]; p.Status != StatusCancelled || p.StopReason != StopReasonCancelled {
+		t.Errorf("cancelled: %s, %s, want cancelled", p.Status, p.StopReason)
+	}
+}
@@ -0,0 +1,90 @@
+package findpath
+
+import (
+	"context"
+	"iter"
+)
+
+// StreamPathsFromGrid is like GetPathFromGrid but hands every path to emit as soon as its
+// search is done, in completion order, instead of returning them together. i is the index
+// of the player in players. The calls to emit never overlap and are all done when it
+// returns. Nothing is emitted when the request as a whole fails.
+func (fps *FindPathService) StreamPathsFromGrid(
+	ctx context.Context,
+	g *Grid,
+	players []*Player,
+	emit func(i int, p *Path),
+) error {
+	gameMap, err := toGameMap(g, players)
+	if err != nil {
+		return err
+	}
+
+	_, err = fps.computePaths(ctx, gameMap, emit)
+
+	return err
+}
+
+// StreamPathsOnCompiledMap is like GetPathOnCompiledMap but hands every path to emit as soon
+// as its search is done, the same way as StreamPathsFromGrid.
+func (fps *FindPathService) StreamPathsOnCompiledMap(
+	ctx context.Context,
+	cm *CompiledMap,
+	players []*Player,
+	emit func(i int, p *Path),
+) error {
+	_, err := fps.searchPaths(ctx, cm.gameMap, toModelPlayers(players), cm.fingerprint, emit)
+
+	return err
+}
+
+// PathsFromGrid is like StreamPathsFromGrid as an iterator: it yields the path of every
+// player in completion order, PlayerID telling which one it is. When the request fails as
+// a whole, it yields the error alone. Breaking out of the loop cancels the searches left.
+func (fps *FindPathService) PathsFromGrid(ctx context.Context, g *Grid, players []*Player) iter.Seq2[*Path, error] {
+	return pathSeq(ctx, len(players), func(ctx context.Context, emit func(i int, p *Path)) error {
+		return fps.StreamPathsFromGrid(ctx, g, players, emit)
+	})
+}
+
+// PathsOnCompiledMap is like StreamPathsOnCompiledMap as an iterator, the same way as
+// PathsFromGrid.
+func (fps *FindPathService) PathsOnCompiledMap(ctx context.Context, cm *CompiledMap, players []*Player) iter.Seq2[*Path, error] {
+	return pathSeq(ctx, len(players), func(ctx context.Context, emit func(i int, p *Path)) error {
+		return fps.StreamPathsOnCompiledMap(ctx, cm, players, emit)
+	})
+}
+
+// pathSeq turns a streaming search of n players into an iterator. The channel holds every
+// path, so the workers never wait for the loop body.
+func pathSeq(
+	ctx context.Context,
+	n int,
+	stream func(ctx context.Context, emit func(i int, p *Path)) error,
+) iter.Seq2[*Path, error] {
+	return func(yield func(*Path, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		paths := make(chan *Path, n)
+		done := make(chan error, 1)
+
+		go func() {
+			defer close(paths)
+
+			done <- stream(ctx, func(_ int, p *Path) {
+				paths <- p
+			})
+		}()
+
+		for p := range paths {
+			if !yield(p, nil) {
+				return
+			}
+		}
+
+		if err := <-done; err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -1,17 +1,190 @@
 package findpath
 
+import "time"
+
 type Node struct {
 	Y int32 `json:"y"`
 	X int32 `json:"x"`
+	Z int32 `json:"z,omitempty"` // level, 0 on single level maps
 }
 
 type Player struct {
-	Start  Node `json:"start"`
-	Target Node `json:"target"`
+	ID       string            `json:"id,omitempty"` // returned as Path.PlayerID, the index of the player when empty
+	Start    Node              `json:"start"`
+	Target   Node              `json:"target"`
+	Heading  Direction         `json:"heading"`            // initial heading, only used on maps with turn rules
+	Metadata map[string]string `json:"metadata,omitempty"` // opaque to the service, returned as Path.Metadata
 }
 
+// Heuristic is the estimate A* adds to the cost of reaching a cell.
+type Heuristic string
+
+const (
+	HeuristicDistance Heuristic = ""     // least steps to the target, the default
+	HeuristicNone     Heuristic = "none" // A* expands cells in the same order as Dijkstra
+)
+
+type Direction string
+
+const (
+	DirectionNone  Direction = ""
+	DirectionUp    Direction = "up" // towards y = 0
+	DirectionDown  Direction = "down"
+	DirectionLeft  Direction = "left" // towards x = 0
+	DirectionRight Direction = "right"
+)
+
 type Path struct {
-	PlayerID string  `json:"player_id"`
-	Found    bool    `json:"found"`
-	Steps    []*Node `json:"steps"`
+	PlayerID   string   `json:"player_id"`
+	Found      bool     `json:"found"`
+	Status     Status   `json:"status"`
+	Message    string   `json:"message,omitempty"` // why the status isn't StatusOK
+	Steps      []*Node  `json:"steps"`
+	StopReason string   `json:"stop_reason"` // why the search stopped, one of the StopReason* values
+	Stats      *Stats   `json:"stats"`
+	Routes     []*Route `json:"routes,omitempty"`  // ranked alternatives, best first; see SetKShortestPaths
+	Cached     bool     `json:"cached,omitempty"`  // taken from the path cache, Stats are of the original search
+	Partial    bool     `json:"partial,omitempty"` // not found, Steps lead to the cell closest to the target; see SetPartialPaths
+
+	Metadata map[string]string `json:"metadata,omitempty"` // of the player, as given
+
+	err error
+}
+
+// Err returns the FieldErrors of a player with StatusInvalidStart or StatusInvalidTarget,
+// nil for other players.
+func (p *Path) Err() error {
+	return p.err
+}
+
+// Status tells what came of the search of one player. A bad player gets its own status
+// instead of failing the others.
+type Status string
+
+const (
+	StatusOK             Status = "ok"
+	StatusNoPath         Status = "no_path"
+	StatusInvalidStart   Status = "invalid_start"  // off the map, on a blocked cell or with an unknown heading
+	StatusInvalidTarget  Status = "invalid_target" // off the map or on a blocked cell
+	StatusCancelled      Status = "cancelled"
+	StatusBudgetExceeded Status = "budget_exceeded"
+)
+
+type Route struct {
+	Steps []*Node `json:"steps"`
+	Cost  int32   `json:"cost"`
+}
+
+// Stats describes the work done while searching a path.
+type Stats struct {
+	Cost           int32         `json:"cost"` // total cost of the path, 0 if not found
+	NodesExpanded  int           `json:"nodes_expanded"`
+	NodesGenerated int           `json:"nodes_generated"`
+	PeakOpen       int           `json:"peak_open"` // max size of the open list
+	Duration       time.Duration `json:"duration"`
+}
+
+const (
+	StopReasonFound           = "found"
+	StopReasonNoPath          = "no_path"
+	StopReasonCancelled       = "cancelled"
+	StopReasonBudgetExceeded  = "budget_exceeded"
+	StopReasonDifferentRegion = "different_region" // start and target can't be connected, no search was run
+	StopReasonInvalidPlayer   = "invalid_player"   // the player has a bad start or target, no search was run
+)
+
+// Grid is a flat map with optional extras, see GetPathFromGrid.
+type Grid struct {
+	Width  int32   `json:"width"`
+	Height int32   `json:"height"`
+	Depth  int32   `json:"depth"` // number of levels, 0 means 1
+	Cells  []int32 `json:"cells"` // flat Depth*Width*Height array, level by level, 0 is walkable
+
+	Connectors []*Connector `json:"connectors"` // stairs and elevators between levels
+
+	// Connectivity turns the levels into a voxel grid where cells of neighbouring levels
+	// are adjacent. Turn rules and exits can't be used with it.
+	Connectivity Connectivity `json:"connectivity"`
+
+	CostLayers   []*CostLayer       `json:"cost_layers"`
+	LayerWeights map[string]float64 `json:"layer_weights"` // by layer name, missing layers weigh 1
+
+	TurnCost int32 `json:"turn_cost"` // added for every 90° change of direction
+	MaxTurns int32 `json:"max_turns"` // max 90° direction changes per path, 0 - no limit
+
+	Wrap    Wrap         `json:"wrap"` // edges that connect to the opposite side
+	Portals []*Portal    `json:"portals"`
+	Exits   []*CellExits `json:"exits"` // cells that can only be left in some directions
+}
+
+// Connectivity is the number of neighbours of a voxel.
+type Connectivity int32
+
+const (
+	ConnectivityLevels Connectivity = 0  // 4 neighbours within a level, levels joined by connectors only
+	Connectivity6      Connectivity = 6  // faces
+	Connectivity18     Connectivity = 18 // faces and edges
+	Connectivity26     Connectivity = 26 // faces, edges and corners
+)
+
+type Wrap string
+
+const (
+	WrapNone       Wrap = ""
+	WrapHorizontal Wrap = "horizontal" // x = 0 and x = Width-1 are adjacent
+	WrapVertical   Wrap = "vertical"   // y = 0 and y = Height-1 are adjacent
+	WrapBoth       Wrap = "both"
+)
+
+// Portal is a directed edge between any two cells: a teleport pad, a ladder or a door.
+// Add a second portal for the way back.
+type Portal struct {
+	From Node  `json:"from"`
+	To   Node  `json:"to"`
+	Cost int32 `json:"cost"`
+}
+
+// Connector links cells on different levels, e.g. both ends of stairs or every floor of an
+// elevator shaft. Each pair of its cells is connected both ways, Cost is paid per level travelled.
+type Connector struct {
+	Kind  string `json:"kind"` // informational, e.g. "stairs" or "elevator"
+	Cells []Node `json:"cells"`
+	Cost  int32  `json:"cost"`
+}
+
+// CellExits limits the directions a cell can be left in, e.g. for one-way conveyor tiles.
+// Portals starting at the cell are not affected.
+type CellExits struct {
+	Node
+	Allow []Direction `json:"allow"`
+}
+
+// CostLayer makes cells more expensive to enter without blocking them.
+// Cells, Dense and Sources may be combined within one layer.
+type CostLayer struct {
+	Name    string             `json:"name"`
+	Cells   []*CellCost        `json:"cells"`   // sparse
+	Dense   []int32            `json:"dense"`   // flat Depth*Width*Height array
+	Sources []*InfluenceSource `json:"sources"` // radial, spread within their own level
+}
+
+type CellCost struct {
+	Node
+	Cost int32 `json:"cost"`
+}
+
+type Falloff string
+
+const (
+	FalloffConstant  Falloff = "constant"
+	FalloffLinear    Falloff = "linear"
+	FalloffQuadratic Falloff = "quadratic"
+)
+
+// InfluenceSource spreads Strength around a cell, fading out to nothing at Radius.
+type InfluenceSource struct {
+	Node
+	Strength int32   `json:"strength"`
+	Radius   int32   `json:"radius"`
+	Falloff  Falloff `json:"falloff"` // linear by default
 }
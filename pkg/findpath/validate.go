@@ -0,0 +1,148 @@
+package findpath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/unomns/findpath/internal/algorithms"
+	"github.com/unomns/findpath/internal/model"
+)
+
+// Errors wrapped by the FieldError of a request that can't be searched.
+var (
+	ErrInvalidGrid    = errors.New("invalid grid")
+	ErrOutOfBounds    = errors.New("out of the map")
+	ErrBlockedStart   = errors.New("start cell is blocked")
+	ErrBlockedTarget  = errors.New("target cell is blocked")
+	ErrInvalidHeading = errors.New("unknown heading")
+)
+
+// FieldError is a value of a request that can't be searched. Err wraps one of the Err values
+// above, so errors.Is tells what is wrong. A bad grid fails the request, a bad player only
+// gets its own status and the errors.Join of its FieldErrors in Path.Err.
+type FieldError struct {
+	Player int    // index of the player in the request, -1 when the error is not about a player
+	Field  string // path of the value, e.g. "grid.cells" or "players[2].start"
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors returns every FieldError in err, nil when it has none.
+func FieldErrors(err error) []*FieldError {
+	var res []*FieldError
+
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case *FieldError:
+			res = append(res, e)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+
+	walk(err)
+
+	return res
+}
+
+// joinFieldErrors describes the FieldErrors of err on one line.
+func joinFieldErrors(err error) string {
+	fields := FieldErrors(err)
+
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// validateGrid checks the dimensions of the grid against its cells.
+func validateGrid(g *Grid) error {
+	invalid := func(field string, format string, args ...any) error {
+		return &FieldError{
+			Player: -1,
+			Field:  "grid." + field,
+			Err:    fmt.Errorf("%w: "+format, append([]any{ErrInvalidGrid}, args...)...),
+		}
+	}
+
+	switch {
+	case g.Width <= 0:
+		return invalid("width", "%d is not positive", g.Width)
+	case g.Height <= 0:
+		return invalid("height", "%d is not positive", g.Height)
+	case g.Depth < 0:
+		return invalid("depth", "%d is negative", g.Depth)
+	}
+
+	cells := int64(g.Width) * int64(g.Height) * int64(max(1, g.Depth))
+	if int64(len(g.Cells)) != cells {
+		return invalid("cells", "%d cells, width × height × depth is %d", len(g.Cells), cells)
+	}
+
+	return nil
+}
+
+// prepareMap prepares the map for searching. A part of the grid Prepare can't use fails with
+// a FieldError wrapping ErrInvalidGrid.
+func prepareMap(m *model.GameMap) error {
+	err := algorithms.Prepare(m)
+
+	var me *algorithms.MapError
+	if errors.As(err, &me) {
+		return &FieldError{Player: -1, Field: "grid." + me.Field, Err: fmt.Errorf("%w: %v", ErrInvalidGrid, me.Err)}
+	}
+
+	return err
+}
+
+// validatePlayer checks that the player at index i starts and ends on walkable cells of the
+// prepared map and has a known heading. When it doesn't, the status tells which end is wrong,
+// the start first.
+func validatePlayer(m *model.GameMap, i int, p model.Player) (Status, error) {
+	var errs []error
+	status := StatusOK
+
+	invalid := func(field string, s Status, err error) {
+		errs = append(errs, &FieldError{Player: i, Field: fmt.Sprintf("players[%d].%s", i, field), Err: err})
+		if status == StatusOK || s == StatusInvalidStart {
+			status = s
+		}
+	}
+
+	switch Direction(p.Heading) {
+	case DirectionNone, DirectionUp, DirectionDown, DirectionLeft, DirectionRight:
+	default:
+		invalid("heading", StatusInvalidStart, fmt.Errorf("%w %q", ErrInvalidHeading, p.Heading))
+	}
+
+	switch {
+	case !algorithms.InBounds(m, p.Start):
+		invalid("start", StatusInvalidStart, fmt.Errorf("%w: %v", ErrOutOfBounds, p.Start))
+	case !algorithms.Walkable(m, p.Start):
+		invalid("start", StatusInvalidStart, fmt.Errorf("%w: %v", ErrBlockedStart, p.Start))
+	}
+
+	switch {
+	case !algorithms.InBounds(m, p.Target):
+		invalid("target", StatusInvalidTarget, fmt.Errorf("%w: %v", ErrOutOfBounds, p.Target))
+	case !algorithms.Walkable(m, p.Target):
+		invalid("target", StatusInvalidTarget, fmt.Errorf("%w: %v", ErrBlockedTarget, p.Target))
+	}
+
+	return status, errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package findpath
+
+import (
+	"context"
+	"testing"
+)
+
+func TestVolumeConnectivities(t *testing.T) {
+	open, hollow := make([]int32, 27), make([]int32, 27)
+	hollow[13] = 1 // the centre of the 3×3×3 cube
+
+	corner := []*Player{{Target: Node{Z: 2, Y: 2, X: 2}}}
+
+	// from corner to corner of the cube; without the centre, moves that pass by it get longer
+	cases := []struct {
+		connectivity Connectivity
+		cells        []int32
+		cost         int32
+	}{
+		{Connectivity6, open, 6},
+		{Connectivity18, open, 3},
+		{Connectivity26, open, 2},
+		{Connectivity6, hollow, 6},
+		{Connectivity18, hollow, 4},
+		{Connectivity26, hollow, 4},
+	}
+
+	for _, algo := range []string{AlgoAStar, AlgoJumpPoint, "dijkstra"} {
+		service, err := New(algo, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range cases {
+			g := &Grid{Width: 3, Height: 3, Depth: 3, Cells: c.cells, Connectivity: c.connectivity}
+
+			paths, err := service.GetPathFromGrid(context.Background(), g, corner)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := paths[0]
+			if !p.Found || p.Stats.Cost != c.cost || len(p.Steps) != int(c.cost)+1 {
+				t.Errorf("%s, %d-connectivity, centre %d: found %v with cost %d and %d steps, want cost %d",
+					algo, c.connectivity, c.cells[13], p.Found, p.Stats.Cost, len(p.Steps), c.cost)
+			}
+		}
+	}
+
+	// GetPathFromFlatVolume is 26-connected
+	for _, c := range cases {
+		if c.connectivity != Connectivity26 {
+			continue
+		}
+
+		paths, err := newTestService(t).GetPathFromFlatVolume(3, 3, 3, c.cells, corner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := paths[0]; !p.Found || p.Stats.Cost != c.cost {
+			t.Errorf("GetPathFromFlatVolume, centre %d: found %v with cost %d, want cost %d", c.cells[13], p.Found, p.Stats.Cost, c.cost)
+		}
+	}
+
+	_, err := newTestService(t).GetPathFromFlatVolume(3, 3, 2, open, corner)
+	if errs := FieldErrors(err); len(errs) != 1 || errs[0].Field != "grid.cells" {
+		t.Errorf("27 cells for a 3×3×2 volume: %v, want a grid.cells error", err)
+	}
+}
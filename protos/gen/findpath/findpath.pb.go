@@ -9,6 +9,8 @@ package findpathv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,31 +23,2113 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Wrap makes map edges connect to the opposite side.
+type Wrap int32
+
+const (
+	Wrap_WRAP_NONE       Wrap = 0
+	Wrap_WRAP_HORIZONTAL Wrap = 1 // x = 0 and x = width-1 are adjacent
+	Wrap_WRAP_VERTICAL   Wrap = 2 // y = 0 and y = height-1 are adjacent
+	Wrap_WRAP_BOTH       Wrap = 3
+)
+
+// Enum value maps for Wrap.
+var (
+	Wrap_name = map[int32]string{
+		0: "WRAP_NONE",
+		1: "WRAP_HORIZONTAL",
+		2: "WRAP_VERTICAL",
+		3: "WRAP_BOTH",
+	}
+	Wrap_value = map[string]int32{
+		"WRAP_NONE":       0,
+		"WRAP_HORIZONTAL": 1,
+		"WRAP_VERTICAL":   2,
+		"WRAP_BOTH":       3,
+	}
+)
+
+func (x Wrap) Enum() *Wrap {
+	p := new(Wrap)
+	*p = x
+	return p
+}
+
+func (x Wrap) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Wrap) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_findpath_proto_enumTypes[0].Descriptor()
+}
+
+func (Wrap) Type() protoreflect.EnumType {
+	return &file_findpath_findpath_proto_enumTypes[0]
+}
+
+func (x Wrap) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Wrap.Descriptor instead.
+func (Wrap) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{0}
+}
+
+type Connectivity int32
+
+const (
+	Connectivity_CONNECTIVITY_26 Connectivity = 0 // faces, edges and corners
+	Connectivity_CONNECTIVITY_18 Connectivity = 1 // faces and edges
+	Connectivity_CONNECTIVITY_6  Connectivity = 2 // faces
+)
+
+// Enum value maps for Connectivity.
+var (
+	Connectivity_name = map[int32]string{
+		0: "CONNECTIVITY_26",
+		1: "CONNECTIVITY_18",
+		2: "CONNECTIVITY_6",
+	}
+	Connectivity_value = map[string]int32{
+		"CONNECTIVITY_26": 0,
+		"CONNECTIVITY_18": 1,
+		"CONNECTIVITY_6":  2,
+	}
+)
+
+func (x Connectivity) Enum() *Connectivity {
+	p := new(Connectivity)
+	*p = x
+	return p
+}
+
+func (x Connectivity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Connectivity) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_findpath_proto_enumTypes[1].Descriptor()
+}
+
+func (Connectivity) Type() protoreflect.EnumType {
+	return &file_findpath_findpath_proto_enumTypes[1]
+}
+
+func (x Connectivity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Connectivity.Descriptor instead.
+func (Connectivity) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{1}
+}
+
+type Direction int32
+
+const (
+	Direction_NONE  Direction = 0
+	Direction_UP    Direction = 1 // towards y = 0
+	Direction_DOWN  Direction = 2
+	Direction_LEFT  Direction = 3 // towards x = 0
+	Direction_RIGHT Direction = 4
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "NONE",
+		1: "UP",
+		2: "DOWN",
+		3: "LEFT",
+		4: "RIGHT",
+	}
+	Direction_value = map[string]int32{
+		"NONE":  0,
+		"UP":    1,
+		"DOWN":  2,
+		"LEFT":  3,
+		"RIGHT": 4,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_findpath_proto_enumTypes[2].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_findpath_findpath_proto_enumTypes[2]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{2}
+}
+
+type InfluenceSource_Falloff int32
+
+const (
+	InfluenceSource_LINEAR    InfluenceSource_Falloff = 0
+	InfluenceSource_CONSTANT  InfluenceSource_Falloff = 1
+	InfluenceSource_QUADRATIC InfluenceSource_Falloff = 2
+)
+
+// Enum value maps for InfluenceSource_Falloff.
+var (
+	InfluenceSource_Falloff_name = map[int32]string{
+		0: "LINEAR",
+		1: "CONSTANT",
+		2: "QUADRATIC",
+	}
+	InfluenceSource_Falloff_value = map[string]int32{
+		"LINEAR":    0,
+		"CONSTANT":  1,
+		"QUADRATIC": 2,
+	}
+)
+
+func (x InfluenceSource_Falloff) Enum() *InfluenceSource_Falloff {
+	p := new(InfluenceSource_Falloff)
+	*p = x
+	return p
+}
+
+func (x InfluenceSource_Falloff) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InfluenceSource_Falloff) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_findpath_proto_enumTypes[3].Descriptor()
+}
+
+func (InfluenceSource_Falloff) Type() protoreflect.EnumType {
+	return &file_findpath_findpath_proto_enumTypes[3]
+}
+
+func (x InfluenceSource_Falloff) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InfluenceSource_Falloff.Descriptor instead.
+func (InfluenceSource_Falloff) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{6, 0}
+}
+
+// Status tells what came of the search of one player, a bad player doesn't fail the others.
+type Path_Status int32
+
+const (
+	Path_STATUS_UNSPECIFIED Path_Status = 0
+	Path_OK                 Path_Status = 1
+	Path_NO_PATH            Path_Status = 2
+	Path_INVALID_START      Path_Status = 3 // off the map, on a blocked cell or with an unknown heading
+	Path_INVALID_TARGET     Path_Status = 4 // off the map or on a blocked cell
+	Path_CANCELLED          Path_Status = 5
+	Path_BUDGET_EXCEEDED    Path_Status = 6
+)
+
+// Enum value maps for Path_Status.
+var (
+	Path_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "OK",
+		2: "NO_PATH",
+		3: "INVALID_START",
+		4: "INVALID_TARGET",
+		5: "CANCELLED",
+		6: "BUDGET_EXCEEDED",
+	}
+	Path_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"OK":                 1,
+		"NO_PATH":            2,
+		"INVALID_START":      3,
+		"INVALID_TARGET":     4,
+		"CANCELLED":          5,
+		"BUDGET_EXCEEDED":    6,
+	}
+)
+
+func (x Path_Status) Enum() *Path_Status {
+	p := new(Path_Status)
+	*p = x
+	return p
+}
+
+func (x Path_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Path_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_findpath_proto_enumTypes[4].Descriptor()
+}
+
+func (Path_Status) Type() protoreflect.EnumType {
+	return &file_findpath_findpath_proto_enumTypes[4]
+}
+
+func (x Path_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Path_Status.Descriptor instead.
+func (Path_Status) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{32, 0}
+}
+
 type PathRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
-	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
-	Grid          []int32                `protobuf:"varint,3,rep,packed,name=grid,proto3" json:"grid,omitempty"` // flat array
-	Players       []*Player              `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Grid          []int32                `protobuf:"varint,3,rep,packed,name=grid,proto3" json:"grid,omitempty"` // flat array, level by level
+	Players       []*Player              `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	KPaths        int32                  `protobuf:"varint,5,opt,name=k_paths,json=kPaths,proto3" json:"k_paths,omitempty"`              // > 1 returns up to k ranked routes per player
+	MaxOverlap    float64                `protobuf:"fixed64,6,opt,name=max_overlap,json=maxOverlap,proto3" json:"max_overlap,omitempty"` // skip routes sharing more than this share (0..1) of cells with a better one, 0 - off
+	CostLayers    []*CostLayer           `protobuf:"bytes,7,rep,name=cost_layers,json=costLayers,proto3" json:"cost_layers,omitempty"`
+	LayerWeights  map[string]float64     `protobuf:"bytes,8,rep,name=layer_weights,json=layerWeights,proto3" json:"layer_weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // by layer name, missing layers weigh 1
+	TurnCost      int32                  `protobuf:"varint,9,opt,name=turn_cost,json=turnCost,proto3" json:"turn_cost,omitempty"`                                                                                        // added for every 90° change of direction
+	MaxTurns      int32                  `protobuf:"varint,10,opt,name=max_turns,json=maxTurns,proto3" json:"max_turns,omitempty"`                                                                                       // max 90° direction changes per path, 0 - no limit
+	Portals       []*Portal              `protobuf:"bytes,11,rep,name=portals,proto3" json:"portals,omitempty"`
+	Exits         []*CellExits           `protobuf:"bytes,12,rep,name=exits,proto3" json:"exits,omitempty"` // cells that can only be left in some directions
+	Wrap          Wrap                   `protobuf:"varint,13,opt,name=wrap,proto3,enum=findpath.Wrap" json:"wrap,omitempty"`
+	Depth         int32                  `protobuf:"varint,14,opt,name=depth,proto3" json:"depth,omitempty"`          // number of levels stacked in grid, 0 means 1
+	Connectors    []*Connector           `protobuf:"bytes,15,rep,name=connectors,proto3" json:"connectors,omitempty"` // stairs and elevators between levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathRequest) Reset() {
+	*x = PathRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathRequest) ProtoMessage() {}
+
+func (x *PathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
+func (*PathRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PathRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *PathRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *PathRequest) GetGrid() []int32 {
+	if x != nil {
+		return x.Grid
+	}
+	return nil
+}
+
+func (x *PathRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PathRequest) GetKPaths() int32 {
+	if x != nil {
+		return x.KPaths
+	}
+	return 0
+}
+
+func (x *PathRequest) GetMaxOverlap() float64 {
+	if x != nil {
+		return x.MaxOverlap
+	}
+	return 0
+}
+
+func (x *PathRequest) GetCostLayers() []*CostLayer {
+	if x != nil {
+		return x.CostLayers
+	}
+	return nil
+}
+
+func (x *PathRequest) GetLayerWeights() map[string]float64 {
+	if x != nil {
+		return x.LayerWeights
+	}
+	return nil
+}
+
+func (x *PathRequest) GetTurnCost() int32 {
+	if x != nil {
+		return x.TurnCost
+	}
+	return 0
+}
+
+func (x *PathRequest) GetMaxTurns() int32 {
+	if x != nil {
+		return x.MaxTurns
+	}
+	return 0
+}
+
+func (x *PathRequest) GetPortals() []*Portal {
+	if x != nil {
+		return x.Portals
+	}
+	return nil
+}
+
+func (x *PathRequest) GetExits() []*CellExits {
+	if x != nil {
+		return x.Exits
+	}
+	return nil
+}
+
+func (x *PathRequest) GetWrap() Wrap {
+	if x != nil {
+		return x.Wrap
+	}
+	return Wrap_WRAP_NONE
+}
+
+func (x *PathRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *PathRequest) GetConnectors() []*Connector {
+	if x != nil {
+		return x.Connectors
+	}
+	return nil
+}
+
+// Connector links cells on different levels. Each pair of its cells is connected both ways,
+// cost is paid per level travelled.
+type Connector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // informational, e.g. "stairs" or "elevator"
+	Cells         []*Node                `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	Cost          int32                  `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connector) Reset() {
+	*x = Connector{}
+	mi := &file_findpath_findpath_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connector) ProtoMessage() {}
+
+func (x *Connector) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connector.ProtoReflect.Descriptor instead.
+func (*Connector) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Connector) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Connector) GetCells() []*Node {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Connector) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+// Portal is a directed edge between any two cells: a teleport pad, a ladder or a door.
+type Portal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Node                  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Node                  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Cost          int32                  `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Portal) Reset() {
+	*x = Portal{}
+	mi := &file_findpath_findpath_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Portal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Portal) ProtoMessage() {}
+
+func (x *Portal) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Portal.ProtoReflect.Descriptor instead.
+func (*Portal) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Portal) GetFrom() *Node {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Portal) GetTo() *Node {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Portal) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+// CellExits limits the directions a cell can be left in, portals are not affected.
+type CellExits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          *Node                  `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Allow         []Direction            `protobuf:"varint,2,rep,packed,name=allow,proto3,enum=findpath.Direction" json:"allow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellExits) Reset() {
+	*x = CellExits{}
+	mi := &file_findpath_findpath_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellExits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellExits) ProtoMessage() {}
+
+func (x *CellExits) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellExits.ProtoReflect.Descriptor instead.
+func (*CellExits) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CellExits) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellExits) GetAllow() []Direction {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+// CostLayer makes cells more expensive to enter without blocking them.
+type CostLayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cells         []*CellCost            `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`         // sparse
+	Dense         []int32                `protobuf:"varint,3,rep,packed,name=dense,proto3" json:"dense,omitempty"` // flat depth*width*height array
+	Sources       []*InfluenceSource     `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`     // radial
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostLayer) Reset() {
+	*x = CostLayer{}
+	mi := &file_findpath_findpath_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostLayer) ProtoMessage() {}
+
+func (x *CostLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostLayer.ProtoReflect.Descriptor instead.
+func (*CostLayer) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CostLayer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CostLayer) GetCells() []*CellCost {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *CostLayer) GetDense() []int32 {
+	if x != nil {
+		return x.Dense
+	}
+	return nil
+}
+
+func (x *CostLayer) GetSources() []*InfluenceSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type CellCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          *Node                  `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Cost          int32                  `protobuf:"varint,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellCost) Reset() {
+	*x = CellCost{}
+	mi := &file_findpath_findpath_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellCost) ProtoMessage() {}
+
+func (x *CellCost) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellCost.ProtoReflect.Descriptor instead.
+func (*CellCost) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CellCost) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellCost) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type InfluenceSource struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Cell          *Node                   `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Strength      int32                   `protobuf:"varint,2,opt,name=strength,proto3" json:"strength,omitempty"`
+	Radius        int32                   `protobuf:"varint,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	Falloff       InfluenceSource_Falloff `protobuf:"varint,4,opt,name=falloff,proto3,enum=findpath.InfluenceSource_Falloff" json:"falloff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfluenceSource) Reset() {
+	*x = InfluenceSource{}
+	mi := &file_findpath_findpath_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfluenceSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfluenceSource) ProtoMessage() {}
+
+func (x *InfluenceSource) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfluenceSource.ProtoReflect.Descriptor instead.
+func (*InfluenceSource) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InfluenceSource) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *InfluenceSource) GetStrength() int32 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *InfluenceSource) GetRadius() int32 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *InfluenceSource) GetFalloff() InfluenceSource_Falloff {
+	if x != nil {
+		return x.Falloff
+	}
+	return InfluenceSource_LINEAR
+}
+
+// VolumeRequest searches a 3D occupancy grid, nodes use all of x, y and z.
+type VolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Depth         int32                  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	Cells         []int32                `protobuf:"varint,4,rep,packed,name=cells,proto3" json:"cells,omitempty"` // flat array, level by level
+	Players       []*Player              `protobuf:"bytes,5,rep,name=players,proto3" json:"players,omitempty"`
+	Connectivity  Connectivity           `protobuf:"varint,6,opt,name=connectivity,proto3,enum=findpath.Connectivity" json:"connectivity,omitempty"`
+	Wrap          Wrap                   `protobuf:"varint,7,opt,name=wrap,proto3,enum=findpath.Wrap" json:"wrap,omitempty"` // x and y only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeRequest) Reset() {
+	*x = VolumeRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeRequest) ProtoMessage() {}
+
+func (x *VolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeRequest.ProtoReflect.Descriptor instead.
+func (*VolumeRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VolumeRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *VolumeRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *VolumeRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *VolumeRequest) GetCells() []int32 {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *VolumeRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *VolumeRequest) GetConnectivity() Connectivity {
+	if x != nil {
+		return x.Connectivity
+	}
+	return Connectivity_CONNECTIVITY_26
+}
+
+func (x *VolumeRequest) GetWrap() Wrap {
+	if x != nil {
+		return x.Wrap
+	}
+	return Wrap_WRAP_NONE
+}
+
+// NavMeshRequest searches a set of convex polygons, polygons sharing both vertices
+// of an edge are connected through it.
+type NavMeshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertices      []*Point               `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	Polygons      []*Polygon             `protobuf:"bytes,2,rep,name=polygons,proto3" json:"polygons,omitempty"`
+	Players       []*NavPlayer           `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NavMeshRequest) Reset() {
+	*x = NavMeshRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NavMeshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavMeshRequest) ProtoMessage() {}
+
+func (x *NavMeshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavMeshRequest.ProtoReflect.Descriptor instead.
+func (*NavMeshRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NavMeshRequest) GetVertices() []*Point {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+func (x *NavMeshRequest) GetPolygons() []*Polygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+func (x *NavMeshRequest) GetPlayers() []*NavPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type Polygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertices      []int32                `protobuf:"varint,1,rep,packed,name=vertices,proto3" json:"vertices,omitempty"` // indices into NavMeshRequest.vertices
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	mi := &file_findpath_findpath_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Polygon) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type NavPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *Point                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Target        *Point                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // returned as NavPath.player_id, the index of the player when empty
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // opaque to the server, returned as NavPath.metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NavPlayer) Reset() {
+	*x = NavPlayer{}
+	mi := &file_findpath_findpath_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NavPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavPlayer) ProtoMessage() {}
+
+func (x *NavPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavPlayer.ProtoReflect.Descriptor instead.
+func (*NavPlayer) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NavPlayer) GetStart() *Point {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *NavPlayer) GetTarget() *Point {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *NavPlayer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NavPlayer) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_findpath_findpath_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type NavMeshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*NavPath             `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NavMeshResponse) Reset() {
+	*x = NavMeshResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NavMeshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavMeshResponse) ProtoMessage() {}
+
+func (x *NavMeshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavMeshResponse.ProtoReflect.Descriptor instead.
+func (*NavMeshResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NavMeshResponse) GetPaths() []*NavPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type NavPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	Points        []*Point               `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"` // corners of the smoothed path
+	Length        float64                `protobuf:"fixed64,4,opt,name=length,proto3" json:"length,omitempty"`
+	Stats         *SearchStats           `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // of the player, as given
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NavPath) Reset() {
+	*x = NavPath{}
+	mi := &file_findpath_findpath_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NavPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavPath) ProtoMessage() {}
+
+func (x *NavPath) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavPath.ProtoReflect.Descriptor instead.
+func (*NavPath) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NavPath) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *NavPath) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *NavPath) GetPoints() []*Point {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *NavPath) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *NavPath) GetStats() *SearchStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *NavPath) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// UploadMapRequest holds a map without players, its fields mean the same as in PathRequest.
+type UploadMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Grid          []int32                `protobuf:"varint,3,rep,packed,name=grid,proto3" json:"grid,omitempty"` // flat array, level by level
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`      // number of levels stacked in grid, 0 means 1
+	Connectors    []*Connector           `protobuf:"bytes,5,rep,name=connectors,proto3" json:"connectors,omitempty"`
+	Voxel         bool                   `protobuf:"varint,6,opt,name=voxel,proto3" json:"voxel,omitempty"` // search the levels as a voxel grid with the connectivity below
+	Connectivity  Connectivity           `protobuf:"varint,7,opt,name=connectivity,proto3,enum=findpath.Connectivity" json:"connectivity,omitempty"`
+	CostLayers    []*CostLayer           `protobuf:"bytes,8,rep,name=cost_layers,json=costLayers,proto3" json:"cost_layers,omitempty"`
+	LayerWeights  map[string]float64     `protobuf:"bytes,9,rep,name=layer_weights,json=layerWeights,proto3" json:"layer_weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	TurnCost      int32                  `protobuf:"varint,10,opt,name=turn_cost,json=turnCost,proto3" json:"turn_cost,omitempty"`
+	MaxTurns      int32                  `protobuf:"varint,11,opt,name=max_turns,json=maxTurns,proto3" json:"max_turns,omitempty"`
+	Portals       []*Portal              `protobuf:"bytes,12,rep,name=portals,proto3" json:"portals,omitempty"`
+	Exits         []*CellExits           `protobuf:"bytes,13,rep,name=exits,proto3" json:"exits,omitempty"`
+	Wrap          Wrap                   `protobuf:"varint,14,opt,name=wrap,proto3,enum=findpath.Wrap" json:"wrap,omitempty"`
+	Components    bool                   `protobuf:"varint,15,opt,name=components,proto3" json:"components,omitempty"` // label regions, so targets in another region are rejected without a search
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMapRequest) Reset() {
+	*x = UploadMapRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMapRequest) ProtoMessage() {}
+
+func (x *UploadMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMapRequest.ProtoReflect.Descriptor instead.
+func (*UploadMapRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UploadMapRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *UploadMapRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *UploadMapRequest) GetGrid() []int32 {
+	if x != nil {
+		return x.Grid
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *UploadMapRequest) GetConnectors() []*Connector {
+	if x != nil {
+		return x.Connectors
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetVoxel() bool {
+	if x != nil {
+		return x.Voxel
+	}
+	return false
+}
+
+func (x *UploadMapRequest) GetConnectivity() Connectivity {
+	if x != nil {
+		return x.Connectivity
+	}
+	return Connectivity_CONNECTIVITY_26
+}
+
+func (x *UploadMapRequest) GetCostLayers() []*CostLayer {
+	if x != nil {
+		return x.CostLayers
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetLayerWeights() map[string]float64 {
+	if x != nil {
+		return x.LayerWeights
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetTurnCost() int32 {
+	if x != nil {
+		return x.TurnCost
+	}
+	return 0
+}
+
+func (x *UploadMapRequest) GetMaxTurns() int32 {
+	if x != nil {
+		return x.MaxTurns
+	}
+	return 0
+}
+
+func (x *UploadMapRequest) GetPortals() []*Portal {
+	if x != nil {
+		return x.Portals
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetExits() []*CellExits {
+	if x != nil {
+		return x.Exits
+	}
+	return nil
+}
+
+func (x *UploadMapRequest) GetWrap() Wrap {
+	if x != nil {
+		return x.Wrap
+	}
+	return Wrap_WRAP_NONE
+}
+
+func (x *UploadMapRequest) GetComponents() bool {
+	if x != nil {
+		return x.Components
+	}
+	return false
+}
+
+type UploadMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Map           *MapInfo               `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMapResponse) Reset() {
+	*x = UploadMapResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMapResponse) ProtoMessage() {}
+
+func (x *UploadMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMapResponse.ProtoReflect.Descriptor instead.
+func (*UploadMapResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UploadMapResponse) GetMap() *MapInfo {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+type PathOnMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Players       []*Player              `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	KPaths        int32                  `protobuf:"varint,3,opt,name=k_paths,json=kPaths,proto3" json:"k_paths,omitempty"`
+	MaxOverlap    float64                `protobuf:"fixed64,4,opt,name=max_overlap,json=maxOverlap,proto3" json:"max_overlap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathOnMapRequest) Reset() {
+	*x = PathOnMapRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathOnMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathOnMapRequest) ProtoMessage() {}
+
+func (x *PathOnMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathOnMapRequest.ProtoReflect.Descriptor instead.
+func (*PathOnMapRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PathOnMapRequest) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *PathOnMapRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PathOnMapRequest) GetKPaths() int32 {
+	if x != nil {
+		return x.KPaths
+	}
+	return 0
+}
+
+func (x *PathOnMapRequest) GetMaxOverlap() float64 {
+	if x != nil {
+		return x.MaxOverlap
+	}
+	return 0
+}
+
+type DeleteMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMapRequest) Reset() {
+	*x = DeleteMapRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMapRequest) ProtoMessage() {}
+
+func (x *DeleteMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMapRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMapRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteMapRequest) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+type DeleteMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMapResponse) Reset() {
+	*x = DeleteMapResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMapResponse) ProtoMessage() {}
+
+func (x *DeleteMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMapResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMapResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{18}
+}
+
+type ListMapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMapsRequest) Reset() {
+	*x = ListMapsRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapsRequest) ProtoMessage() {}
+
+func (x *ListMapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapsRequest.ProtoReflect.Descriptor instead.
+func (*ListMapsRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{19}
+}
+
+type ListMapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Maps          []*MapInfo             `protobuf:"bytes,1,rep,name=maps,proto3" json:"maps,omitempty"` // most recently uploaded first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMapsResponse) Reset() {
+	*x = ListMapsResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapsResponse) ProtoMessage() {}
+
+func (x *ListMapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapsResponse.ProtoReflect.Descriptor instead.
+func (*ListMapsResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListMapsResponse) GetMaps() []*MapInfo {
+	if x != nil {
+		return x.Maps
+	}
+	return nil
+}
+
+type MapInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	Cells         int64                  `protobuf:"varint,5,opt,name=cells,proto3" json:"cells,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	Version       uint64                 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"` // number of patches applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapInfo) Reset() {
+	*x = MapInfo{}
+	mi := &file_findpath_findpath_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapInfo) ProtoMessage() {}
+
+func (x *MapInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapInfo.ProtoReflect.Descriptor instead.
+func (*MapInfo) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MapInfo) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *MapInfo) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MapInfo) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MapInfo) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *MapInfo) GetCells() int64 {
+	if x != nil {
+		return x.Cells
+	}
+	return 0
+}
+
+func (x *MapInfo) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return nil
+}
+
+func (x *MapInfo) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *MapInfo) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// PatchMapRequest fills the rectangles first, then sets the cells. Either all of it is
+// applied or, on error, nothing.
+type PatchMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Cells         []*CellPatch           `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	Rects         []*RectPatch           `protobuf:"bytes,3,rep,name=rects,proto3" json:"rects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchMapRequest) Reset() {
+	*x = PatchMapRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchMapRequest) ProtoMessage() {}
+
+func (x *PatchMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchMapRequest.ProtoReflect.Descriptor instead.
+func (*PatchMapRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PatchMapRequest) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *PatchMapRequest) GetCells() []*CellPatch {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *PatchMapRequest) GetRects() []*RectPatch {
+	if x != nil {
+		return x.Rects
+	}
+	return nil
+}
+
+type CellPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          *Node                  `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Value         int32                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"` // 0 is walkable
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellPatch) Reset() {
+	*x = CellPatch{}
+	mi := &file_findpath_findpath_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellPatch) ProtoMessage() {}
+
+func (x *CellPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellPatch.ProtoReflect.Descriptor instead.
+func (*CellPatch) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CellPatch) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellPatch) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// RectPatch fills the box between two corners, both included.
+type RectPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Node                  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Node                  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Value         int32                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RectPatch) Reset() {
+	*x = RectPatch{}
+	mi := &file_findpath_findpath_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RectPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RectPatch) ProtoMessage() {}
+
+func (x *RectPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RectPatch.ProtoReflect.Descriptor instead.
+func (*RectPatch) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RectPatch) GetFrom() *Node {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RectPatch) GetTo() *Node {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *RectPatch) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type PatchMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Map           *MapInfo               `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchMapResponse) Reset() {
+	*x = PatchMapResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchMapResponse) ProtoMessage() {}
+
+func (x *PatchMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchMapResponse.ProtoReflect.Descriptor instead.
+func (*PatchMapResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PatchMapResponse) GetMap() *MapInfo {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+type PathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          []*Path                `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	MapVersion    uint64                 `protobuf:"varint,2,opt,name=map_version,json=mapVersion,proto3" json:"map_version,omitempty"` // version of the map PathOnMap searched
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathResponse) Reset() {
+	*x = PathResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathResponse) ProtoMessage() {}
+
+func (x *PathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathResponse.ProtoReflect.Descriptor instead.
+func (*PathResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PathResponse) GetPath() []*Path {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PathResponse) GetMapVersion() uint64 {
+	if x != nil {
+		return x.MapVersion
+	}
+	return 0
+}
+
+type PathStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          *Path                  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // player_id tells which player of the request it belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathStreamResponse) Reset() {
+	*x = PathStreamResponse{}
+	mi := &file_findpath_findpath_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathStreamResponse) ProtoMessage() {}
+
+func (x *PathStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathStreamResponse.ProtoReflect.Descriptor instead.
+func (*PathStreamResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PathStreamResponse) GetPath() *Path {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type StreamPathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"` // echoed in every result of the query
+	// Types that are valid to be assigned to Query:
+	//
+	//	*StreamPathRequest_Path
+	//	*StreamPathRequest_OnMap
+	Query         isStreamPathRequest_Query `protobuf_oneof:"query"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PathRequest) Reset() {
-	*x = PathRequest{}
-	mi := &file_findpath_findpath_proto_msgTypes[0]
+func (x *StreamPathRequest) Reset() {
+	*x = StreamPathRequest{}
+	mi := &file_findpath_findpath_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PathRequest) String() string {
+func (x *StreamPathRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathRequest) ProtoMessage() {}
+func (*StreamPathRequest) ProtoMessage() {}
 
-func (x *PathRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_findpath_findpath_proto_msgTypes[0]
+func (x *StreamPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,61 +2140,86 @@ func (x *PathRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
-func (*PathRequest) Descriptor() ([]byte, []int) {
-	return file_findpath_findpath_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use StreamPathRequest.ProtoReflect.Descriptor instead.
+func (*StreamPathRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *PathRequest) GetWidth() int32 {
+func (x *StreamPathRequest) GetCorrelationId() string {
 	if x != nil {
-		return x.Width
+		return x.CorrelationId
 	}
-	return 0
+	return ""
 }
 
-func (x *PathRequest) GetHeight() int32 {
+func (x *StreamPathRequest) GetQuery() isStreamPathRequest_Query {
 	if x != nil {
-		return x.Height
+		return x.Query
 	}
-	return 0
+	return nil
 }
 
-func (x *PathRequest) GetGrid() []int32 {
+func (x *StreamPathRequest) GetPath() *PathRequest {
 	if x != nil {
-		return x.Grid
+		if x, ok := x.Query.(*StreamPathRequest_Path); ok {
+			return x.Path
+		}
 	}
 	return nil
 }
 
-func (x *PathRequest) GetPlayers() []*Player {
+func (x *StreamPathRequest) GetOnMap() *PathOnMapRequest {
 	if x != nil {
-		return x.Players
+		if x, ok := x.Query.(*StreamPathRequest_OnMap); ok {
+			return x.OnMap
+		}
 	}
 	return nil
 }
 
-type PathResponse struct {
+type isStreamPathRequest_Query interface {
+	isStreamPathRequest_Query()
+}
+
+type StreamPathRequest_Path struct {
+	Path *PathRequest `protobuf:"bytes,2,opt,name=path,proto3,oneof"`
+}
+
+type StreamPathRequest_OnMap struct {
+	OnMap *PathOnMapRequest `protobuf:"bytes,3,opt,name=on_map,json=onMap,proto3,oneof"`
+}
+
+func (*StreamPathRequest_Path) isStreamPathRequest_Query() {}
+
+func (*StreamPathRequest_OnMap) isStreamPathRequest_Query() {}
+
+type StreamPathResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          []*Path                `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	PlayerIndex   int32                  `protobuf:"varint,2,opt,name=player_index,json=playerIndex,proto3" json:"player_index,omitempty"` // position of the player in the query, -1 with error
+	Path          *Path                  `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                   // unset with error
+	Error         *StreamError           `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                 // the query failed as a whole
+	Last          bool                   `protobuf:"varint,5,opt,name=last,proto3" json:"last,omitempty"`                                  // no more results follow for the correlation id
+	MapVersion    uint64                 `protobuf:"varint,6,opt,name=map_version,json=mapVersion,proto3" json:"map_version,omitempty"`    // version of the map an on_map query searched
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PathResponse) Reset() {
-	*x = PathResponse{}
-	mi := &file_findpath_findpath_proto_msgTypes[1]
+func (x *StreamPathResult) Reset() {
+	*x = StreamPathResult{}
+	mi := &file_findpath_findpath_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PathResponse) String() string {
+func (x *StreamPathResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathResponse) ProtoMessage() {}
+func (*StreamPathResult) ProtoMessage() {}
 
-func (x *PathResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_findpath_findpath_proto_msgTypes[1]
+func (x *StreamPathResult) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,29 +2230,119 @@ func (x *PathResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathResponse.ProtoReflect.Descriptor instead.
-func (*PathResponse) Descriptor() ([]byte, []int) {
-	return file_findpath_findpath_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use StreamPathResult.ProtoReflect.Descriptor instead.
+func (*StreamPathResult) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *PathResponse) GetPath() []*Path {
+func (x *StreamPathResult) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *StreamPathResult) GetPlayerIndex() int32 {
+	if x != nil {
+		return x.PlayerIndex
+	}
+	return 0
+}
+
+func (x *StreamPathResult) GetPath() *Path {
 	if x != nil {
 		return x.Path
 	}
 	return nil
 }
 
+func (x *StreamPathResult) GetError() *StreamError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *StreamPathResult) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+func (x *StreamPathResult) GetMapVersion() uint64 {
+	if x != nil {
+		return x.MapVersion
+	}
+	return 0
+}
+
+type StreamError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // google.rpc.Code, the one a unary call would fail with
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamError) Reset() {
+	*x = StreamError{}
+	mi := &file_findpath_findpath_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamError) ProtoMessage() {}
+
+func (x *StreamError) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamError.ProtoReflect.Descriptor instead.
+func (*StreamError) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StreamError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *StreamError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type Player struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Start         *Node                  `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
 	Target        *Node                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Heading       Direction              `protobuf:"varint,3,opt,name=heading,proto3,enum=findpath.Direction" json:"heading,omitempty"`                                                    // initial heading, only used with turn rules
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // returned as Path.player_id, the index of the player when empty
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // opaque to the server, returned as Path.metadata
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Player) Reset() {
 	*x = Player{}
-	mi := &file_findpath_findpath_proto_msgTypes[2]
+	mi := &file_findpath_findpath_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +2354,7 @@ func (x *Player) String() string {
 func (*Player) ProtoMessage() {}
 
 func (x *Player) ProtoReflect() protoreflect.Message {
-	mi := &file_findpath_findpath_proto_msgTypes[2]
+	mi := &file_findpath_findpath_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +2367,7 @@ func (x *Player) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Player.ProtoReflect.Descriptor instead.
 func (*Player) Descriptor() ([]byte, []int) {
-	return file_findpath_findpath_proto_rawDescGZIP(), []int{2}
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Player) GetStart() *Node {
@@ -185,18 +2384,44 @@ func (x *Player) GetTarget() *Node {
 	return nil
 }
 
+func (x *Player) GetHeading() Direction {
+	if x != nil {
+		return x.Heading
+	}
+	return Direction_NONE
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type Path struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	Steps         []*Node                `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
 	Found         bool                   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	Stats         *SearchStats           `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	Routes        []*Route               `protobuf:"bytes,5,rep,name=routes,proto3" json:"routes,omitempty"` // ranked alternatives, best first
+	Status        Path_Status            `protobuf:"varint,6,opt,name=status,proto3,enum=findpath.Path_Status" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`                                                                             // why the status isn't OK
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // of the player, as given
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Path) Reset() {
 	*x = Path{}
-	mi := &file_findpath_findpath_proto_msgTypes[3]
+	mi := &file_findpath_findpath_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +2433,7 @@ func (x *Path) String() string {
 func (*Path) ProtoMessage() {}
 
 func (x *Path) ProtoReflect() protoreflect.Message {
-	mi := &file_findpath_findpath_proto_msgTypes[3]
+	mi := &file_findpath_findpath_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +2446,7 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Path.ProtoReflect.Descriptor instead.
 func (*Path) Descriptor() ([]byte, []int) {
-	return file_findpath_findpath_proto_rawDescGZIP(), []int{3}
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *Path) GetPlayerId() string {
@@ -245,17 +2470,181 @@ func (x *Path) GetFound() bool {
 	return false
 }
 
+func (x *Path) GetStats() *SearchStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Path) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *Path) GetStatus() Path_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Path_STATUS_UNSPECIFIED
+}
+
+func (x *Path) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Path) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Route struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*Node                `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	Cost          int32                  `protobuf:"varint,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	mi := &file_findpath_findpath_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Route) GetSteps() []*Node {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Route) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type SearchStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Cost           int32                  `protobuf:"varint,1,opt,name=cost,proto3" json:"cost,omitempty"` // total cost of the path, 0 if not found
+	NodesExpanded  int64                  `protobuf:"varint,2,opt,name=nodes_expanded,json=nodesExpanded,proto3" json:"nodes_expanded,omitempty"`
+	NodesGenerated int64                  `protobuf:"varint,3,opt,name=nodes_generated,json=nodesGenerated,proto3" json:"nodes_generated,omitempty"`
+	PeakOpen       int64                  `protobuf:"varint,4,opt,name=peak_open,json=peakOpen,proto3" json:"peak_open,omitempty"` // max size of the open list
+	Duration       *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchStats) Reset() {
+	*x = SearchStats{}
+	mi := &file_findpath_findpath_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStats) ProtoMessage() {}
+
+func (x *SearchStats) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_findpath_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStats.ProtoReflect.Descriptor instead.
+func (*SearchStats) Descriptor() ([]byte, []int) {
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchStats) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *SearchStats) GetNodesExpanded() int64 {
+	if x != nil {
+		return x.NodesExpanded
+	}
+	return 0
+}
+
+func (x *SearchStats) GetNodesGenerated() int64 {
+	if x != nil {
+		return x.NodesGenerated
+	}
+	return 0
+}
+
+func (x *SearchStats) GetPeakOpen() int64 {
+	if x != nil {
+		return x.PeakOpen
+	}
+	return 0
+}
+
+func (x *SearchStats) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
 type Node struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Y             int32                  `protobuf:"varint,1,opt,name=y,proto3" json:"y,omitempty"`
 	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Z             int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"` // level, 0 on single level maps
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Node) Reset() {
 	*x = Node{}
-	mi := &file_findpath_findpath_proto_msgTypes[4]
+	mi := &file_findpath_findpath_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -267,7 +2656,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_findpath_findpath_proto_msgTypes[4]
+	mi := &file_findpath_findpath_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +2669,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_findpath_findpath_proto_rawDescGZIP(), []int{4}
+	return file_findpath_findpath_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *Node) GetY() int32 {
@@ -297,31 +2686,264 @@ func (x *Node) GetX() int32 {
 	return 0
 }
 
+func (x *Node) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
 var File_findpath_findpath_proto protoreflect.FileDescriptor
 
 const file_findpath_findpath_proto_rawDesc = "" +
 	"\n" +
-	"\x17findpath/findpath.proto\x12\bfindpath\"{\n" +
+	"\x17findpath/findpath.proto\x12\bfindpath\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfa\x04\n" +
 	"\vPathRequest\x12\x14\n" +
 	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
 	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x12\n" +
 	"\x04grid\x18\x03 \x03(\x05R\x04grid\x12*\n" +
-	"\aplayers\x18\x04 \x03(\v2\x10.findpath.PlayerR\aplayers\"2\n" +
+	"\aplayers\x18\x04 \x03(\v2\x10.findpath.PlayerR\aplayers\x12\x17\n" +
+	"\ak_paths\x18\x05 \x01(\x05R\x06kPaths\x12\x1f\n" +
+	"\vmax_overlap\x18\x06 \x01(\x01R\n" +
+	"maxOverlap\x124\n" +
+	"\vcost_layers\x18\a \x03(\v2\x13.findpath.CostLayerR\n" +
+	"costLayers\x12L\n" +
+	"\rlayer_weights\x18\b \x03(\v2'.findpath.PathRequest.LayerWeightsEntryR\flayerWeights\x12\x1b\n" +
+	"\tturn_cost\x18\t \x01(\x05R\bturnCost\x12\x1b\n" +
+	"\tmax_turns\x18\n" +
+	" \x01(\x05R\bmaxTurns\x12*\n" +
+	"\aportals\x18\v \x03(\v2\x10.findpath.PortalR\aportals\x12)\n" +
+	"\x05exits\x18\f \x03(\v2\x13.findpath.CellExitsR\x05exits\x12\"\n" +
+	"\x04wrap\x18\r \x01(\x0e2\x0e.findpath.WrapR\x04wrap\x12\x14\n" +
+	"\x05depth\x18\x0e \x01(\x05R\x05depth\x123\n" +
+	"\n" +
+	"connectors\x18\x0f \x03(\v2\x13.findpath.ConnectorR\n" +
+	"connectors\x1a?\n" +
+	"\x11LayerWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"Y\n" +
+	"\tConnector\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12$\n" +
+	"\x05cells\x18\x02 \x03(\v2\x0e.findpath.NodeR\x05cells\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x05R\x04cost\"`\n" +
+	"\x06Portal\x12\"\n" +
+	"\x04from\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04from\x12\x1e\n" +
+	"\x02to\x18\x02 \x01(\v2\x0e.findpath.NodeR\x02to\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x05R\x04cost\"Z\n" +
+	"\tCellExits\x12\"\n" +
+	"\x04cell\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04cell\x12)\n" +
+	"\x05allow\x18\x02 \x03(\x0e2\x13.findpath.DirectionR\x05allow\"\x94\x01\n" +
+	"\tCostLayer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\x05cells\x18\x02 \x03(\v2\x12.findpath.CellCostR\x05cells\x12\x14\n" +
+	"\x05dense\x18\x03 \x03(\x05R\x05dense\x123\n" +
+	"\asources\x18\x04 \x03(\v2\x19.findpath.InfluenceSourceR\asources\"B\n" +
+	"\bCellCost\x12\"\n" +
+	"\x04cell\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04cell\x12\x12\n" +
+	"\x04cost\x18\x02 \x01(\x05R\x04cost\"\xda\x01\n" +
+	"\x0fInfluenceSource\x12\"\n" +
+	"\x04cell\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04cell\x12\x1a\n" +
+	"\bstrength\x18\x02 \x01(\x05R\bstrength\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x05R\x06radius\x12;\n" +
+	"\afalloff\x18\x04 \x01(\x0e2!.findpath.InfluenceSource.FalloffR\afalloff\"2\n" +
+	"\aFalloff\x12\n" +
+	"\n" +
+	"\x06LINEAR\x10\x00\x12\f\n" +
+	"\bCONSTANT\x10\x01\x12\r\n" +
+	"\tQUADRATIC\x10\x02\"\xf5\x01\n" +
+	"\rVolumeRequest\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\x12\x14\n" +
+	"\x05cells\x18\x04 \x03(\x05R\x05cells\x12*\n" +
+	"\aplayers\x18\x05 \x03(\v2\x10.findpath.PlayerR\aplayers\x12:\n" +
+	"\fconnectivity\x18\x06 \x01(\x0e2\x16.findpath.ConnectivityR\fconnectivity\x12\"\n" +
+	"\x04wrap\x18\a \x01(\x0e2\x0e.findpath.WrapR\x04wrap\"\x9b\x01\n" +
+	"\x0eNavMeshRequest\x12+\n" +
+	"\bvertices\x18\x01 \x03(\v2\x0f.findpath.PointR\bvertices\x12-\n" +
+	"\bpolygons\x18\x02 \x03(\v2\x11.findpath.PolygonR\bpolygons\x12-\n" +
+	"\aplayers\x18\x03 \x03(\v2\x13.findpath.NavPlayerR\aplayers\"%\n" +
+	"\aPolygon\x12\x1a\n" +
+	"\bvertices\x18\x01 \x03(\x05R\bvertices\"\xe7\x01\n" +
+	"\tNavPlayer\x12%\n" +
+	"\x05start\x18\x01 \x01(\v2\x0f.findpath.PointR\x05start\x12'\n" +
+	"\x06target\x18\x02 \x01(\v2\x0f.findpath.PointR\x06target\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12=\n" +
+	"\bmetadata\x18\x04 \x03(\v2!.findpath.NavPlayer.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\":\n" +
+	"\x0fNavMeshResponse\x12'\n" +
+	"\x05paths\x18\x01 \x03(\v2\x11.findpath.NavPathR\x05paths\"\xa4\x02\n" +
+	"\aNavPath\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12'\n" +
+	"\x06points\x18\x03 \x03(\v2\x0f.findpath.PointR\x06points\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x01R\x06length\x12+\n" +
+	"\x05stats\x18\x05 \x01(\v2\x15.findpath.SearchStatsR\x05stats\x12;\n" +
+	"\bmetadata\x18\x06 \x03(\v2\x1f.findpath.NavPath.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x05\n" +
+	"\x10UploadMapRequest\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04grid\x18\x03 \x03(\x05R\x04grid\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x123\n" +
+	"\n" +
+	"connectors\x18\x05 \x03(\v2\x13.findpath.ConnectorR\n" +
+	"connectors\x12\x14\n" +
+	"\x05voxel\x18\x06 \x01(\bR\x05voxel\x12:\n" +
+	"\fconnectivity\x18\a \x01(\x0e2\x16.findpath.ConnectivityR\fconnectivity\x124\n" +
+	"\vcost_layers\x18\b \x03(\v2\x13.findpath.CostLayerR\n" +
+	"costLayers\x12Q\n" +
+	"\rlayer_weights\x18\t \x03(\v2,.findpath.UploadMapRequest.LayerWeightsEntryR\flayerWeights\x12\x1b\n" +
+	"\tturn_cost\x18\n" +
+	" \x01(\x05R\bturnCost\x12\x1b\n" +
+	"\tmax_turns\x18\v \x01(\x05R\bmaxTurns\x12*\n" +
+	"\aportals\x18\f \x03(\v2\x10.findpath.PortalR\aportals\x12)\n" +
+	"\x05exits\x18\r \x03(\v2\x13.findpath.CellExitsR\x05exits\x12\"\n" +
+	"\x04wrap\x18\x0e \x01(\x0e2\x0e.findpath.WrapR\x04wrap\x12\x1e\n" +
+	"\n" +
+	"components\x18\x0f \x01(\bR\n" +
+	"components\x1a?\n" +
+	"\x11LayerWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"8\n" +
+	"\x11UploadMapResponse\x12#\n" +
+	"\x03map\x18\x01 \x01(\v2\x11.findpath.MapInfoR\x03map\"\x8f\x01\n" +
+	"\x10PathOnMapRequest\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12*\n" +
+	"\aplayers\x18\x02 \x03(\v2\x10.findpath.PlayerR\aplayers\x12\x17\n" +
+	"\ak_paths\x18\x03 \x01(\x05R\x06kPaths\x12\x1f\n" +
+	"\vmax_overlap\x18\x04 \x01(\x01R\n" +
+	"maxOverlap\")\n" +
+	"\x10DeleteMapRequest\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\"\x13\n" +
+	"\x11DeleteMapResponse\"\x11\n" +
+	"\x0fListMapsRequest\"9\n" +
+	"\x10ListMapsResponse\x12%\n" +
+	"\x04maps\x18\x01 \x03(\v2\x11.findpath.MapInfoR\x04maps\"\x8f\x02\n" +
+	"\aMapInfo\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x14\n" +
+	"\x05cells\x18\x05 \x01(\x03R\x05cells\x12;\n" +
+	"\vuploaded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"uploadedAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12\x18\n" +
+	"\aversion\x18\b \x01(\x04R\aversion\"~\n" +
+	"\x0fPatchMapRequest\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12)\n" +
+	"\x05cells\x18\x02 \x03(\v2\x13.findpath.CellPatchR\x05cells\x12)\n" +
+	"\x05rects\x18\x03 \x03(\v2\x13.findpath.RectPatchR\x05rects\"E\n" +
+	"\tCellPatch\x12\"\n" +
+	"\x04cell\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04cell\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value\"e\n" +
+	"\tRectPatch\x12\"\n" +
+	"\x04from\x18\x01 \x01(\v2\x0e.findpath.NodeR\x04from\x12\x1e\n" +
+	"\x02to\x18\x02 \x01(\v2\x0e.findpath.NodeR\x02to\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x05R\x05value\"7\n" +
+	"\x10PatchMapResponse\x12#\n" +
+	"\x03map\x18\x01 \x01(\v2\x11.findpath.MapInfoR\x03map\"S\n" +
 	"\fPathResponse\x12\"\n" +
-	"\x04path\x18\x01 \x03(\v2\x0e.findpath.PathR\x04path\"V\n" +
+	"\x04path\x18\x01 \x03(\v2\x0e.findpath.PathR\x04path\x12\x1f\n" +
+	"\vmap_version\x18\x02 \x01(\x04R\n" +
+	"mapVersion\"8\n" +
+	"\x12PathStreamResponse\x12\"\n" +
+	"\x04path\x18\x01 \x01(\v2\x0e.findpath.PathR\x04path\"\xa5\x01\n" +
+	"\x11StreamPathRequest\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12+\n" +
+	"\x04path\x18\x02 \x01(\v2\x15.findpath.PathRequestH\x00R\x04path\x123\n" +
+	"\x06on_map\x18\x03 \x01(\v2\x1a.findpath.PathOnMapRequestH\x00R\x05onMapB\a\n" +
+	"\x05query\"\xe2\x01\n" +
+	"\x10StreamPathResult\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
+	"\fplayer_index\x18\x02 \x01(\x05R\vplayerIndex\x12\"\n" +
+	"\x04path\x18\x03 \x01(\v2\x0e.findpath.PathR\x04path\x12+\n" +
+	"\x05error\x18\x04 \x01(\v2\x15.findpath.StreamErrorR\x05error\x12\x12\n" +
+	"\x04last\x18\x05 \x01(\bR\x04last\x12\x1f\n" +
+	"\vmap_version\x18\x06 \x01(\x04R\n" +
+	"mapVersion\";\n" +
+	"\vStreamError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8e\x02\n" +
 	"\x06Player\x12$\n" +
 	"\x05start\x18\x01 \x01(\v2\x0e.findpath.NodeR\x05start\x12&\n" +
-	"\x06target\x18\x02 \x01(\v2\x0e.findpath.NodeR\x06target\"_\n" +
+	"\x06target\x18\x02 \x01(\v2\x0e.findpath.NodeR\x06target\x12-\n" +
+	"\aheading\x18\x03 \x01(\x0e2\x13.findpath.DirectionR\aheading\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12:\n" +
+	"\bmetadata\x18\x05 \x03(\v2\x1e.findpath.Player.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf8\x03\n" +
 	"\x04Path\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12$\n" +
 	"\x05steps\x18\x02 \x03(\v2\x0e.findpath.NodeR\x05steps\x12\x14\n" +
-	"\x05found\x18\x03 \x01(\bR\x05found\"\"\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\x12+\n" +
+	"\x05stats\x18\x04 \x01(\v2\x15.findpath.SearchStatsR\x05stats\x12'\n" +
+	"\x06routes\x18\x05 \x03(\v2\x0f.findpath.RouteR\x06routes\x12-\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x15.findpath.Path.StatusR\x06status\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x128\n" +
+	"\bmetadata\x18\b \x03(\v2\x1c.findpath.Path.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x06\n" +
+	"\x02OK\x10\x01\x12\v\n" +
+	"\aNO_PATH\x10\x02\x12\x11\n" +
+	"\rINVALID_START\x10\x03\x12\x12\n" +
+	"\x0eINVALID_TARGET\x10\x04\x12\r\n" +
+	"\tCANCELLED\x10\x05\x12\x13\n" +
+	"\x0fBUDGET_EXCEEDED\x10\x06\"A\n" +
+	"\x05Route\x12$\n" +
+	"\x05steps\x18\x01 \x03(\v2\x0e.findpath.NodeR\x05steps\x12\x12\n" +
+	"\x04cost\x18\x02 \x01(\x05R\x04cost\"\xc5\x01\n" +
+	"\vSearchStats\x12\x12\n" +
+	"\x04cost\x18\x01 \x01(\x05R\x04cost\x12%\n" +
+	"\x0enodes_expanded\x18\x02 \x01(\x03R\rnodesExpanded\x12'\n" +
+	"\x0fnodes_generated\x18\x03 \x01(\x03R\x0enodesGenerated\x12\x1b\n" +
+	"\tpeak_open\x18\x04 \x01(\x03R\bpeakOpen\x125\n" +
+	"\bduration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bduration\"0\n" +
 	"\x04Node\x12\f\n" +
 	"\x01y\x18\x01 \x01(\x05R\x01y\x12\f\n" +
-	"\x01x\x18\x02 \x01(\x05R\x01x2C\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x05R\x01z*L\n" +
+	"\x04Wrap\x12\r\n" +
+	"\tWRAP_NONE\x10\x00\x12\x13\n" +
+	"\x0fWRAP_HORIZONTAL\x10\x01\x12\x11\n" +
+	"\rWRAP_VERTICAL\x10\x02\x12\r\n" +
+	"\tWRAP_BOTH\x10\x03*L\n" +
+	"\fConnectivity\x12\x13\n" +
+	"\x0fCONNECTIVITY_26\x10\x00\x12\x13\n" +
+	"\x0fCONNECTIVITY_18\x10\x01\x12\x12\n" +
+	"\x0eCONNECTIVITY_6\x10\x02*<\n" +
+	"\tDirection\x12\b\n" +
+	"\x04NONE\x10\x00\x12\x06\n" +
+	"\x02UP\x10\x01\x12\b\n" +
+	"\x04DOWN\x10\x02\x12\b\n" +
+	"\x04LEFT\x10\x03\x12\t\n" +
+	"\x05RIGHT\x10\x042\xaa\x05\n" +
 	"\n" +
 	"PathFinder\x125\n" +
-	"\x04Path\x12\x15.findpath.PathRequest\x1a\x16.findpath.PathResponseB\x1fZ\x1dunomns.findpath.v1;findpathv1b\x06proto3"
+	"\x04Path\x12\x15.findpath.PathRequest\x1a\x16.findpath.PathResponse\x12C\n" +
+	"\n" +
+	"PathStream\x12\x15.findpath.PathRequest\x1a\x1c.findpath.PathStreamResponse0\x01\x12=\n" +
+	"\n" +
+	"PathVolume\x12\x17.findpath.VolumeRequest\x1a\x16.findpath.PathResponse\x12B\n" +
+	"\vPathNavMesh\x12\x18.findpath.NavMeshRequest\x1a\x19.findpath.NavMeshResponse\x12D\n" +
+	"\tUploadMap\x12\x1a.findpath.UploadMapRequest\x1a\x1b.findpath.UploadMapResponse\x12?\n" +
+	"\tPathOnMap\x12\x1a.findpath.PathOnMapRequest\x1a\x16.findpath.PathResponse\x12D\n" +
+	"\tDeleteMap\x12\x1a.findpath.DeleteMapRequest\x1a\x1b.findpath.DeleteMapResponse\x12A\n" +
+	"\bListMaps\x12\x19.findpath.ListMapsRequest\x1a\x1a.findpath.ListMapsResponse\x12A\n" +
+	"\bPatchMap\x12\x19.findpath.PatchMapRequest\x1a\x1a.findpath.PatchMapResponse\x12J\n" +
+	"\vStreamPaths\x12\x1b.findpath.StreamPathRequest\x1a\x1a.findpath.StreamPathResult(\x010\x01B\x1fZ\x1dunomns.findpath.v1;findpathv1b\x06proto3"
 
 var (
 	file_findpath_findpath_proto_rawDescOnce sync.Once
@@ -335,27 +2957,150 @@ func file_findpath_findpath_proto_rawDescGZIP() []byte {
 	return file_findpath_findpath_proto_rawDescData
 }
 
-var file_findpath_findpath_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_findpath_findpath_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_findpath_findpath_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_findpath_findpath_proto_goTypes = []any{
-	(*PathRequest)(nil),  // 0: findpath.PathRequest
-	(*PathResponse)(nil), // 1: findpath.PathResponse
-	(*Player)(nil),       // 2: findpath.Player
-	(*Path)(nil),         // 3: findpath.Path
-	(*Node)(nil),         // 4: findpath.Node
+	(Wrap)(0),                     // 0: findpath.Wrap
+	(Connectivity)(0),             // 1: findpath.Connectivity
+	(Direction)(0),                // 2: findpath.Direction
+	(InfluenceSource_Falloff)(0),  // 3: findpath.InfluenceSource.Falloff
+	(Path_Status)(0),              // 4: findpath.Path.Status
+	(*PathRequest)(nil),           // 5: findpath.PathRequest
+	(*Connector)(nil),             // 6: findpath.Connector
+	(*Portal)(nil),                // 7: findpath.Portal
+	(*CellExits)(nil),             // 8: findpath.CellExits
+	(*CostLayer)(nil),             // 9: findpath.CostLayer
+	(*CellCost)(nil),              // 10: findpath.CellCost
+	(*InfluenceSource)(nil),       // 11: findpath.InfluenceSource
+	(*VolumeRequest)(nil),         // 12: findpath.VolumeRequest
+	(*NavMeshRequest)(nil),        // 13: findpath.NavMeshRequest
+	(*Polygon)(nil),               // 14: findpath.Polygon
+	(*NavPlayer)(nil),             // 15: findpath.NavPlayer
+	(*Point)(nil),                 // 16: findpath.Point
+	(*NavMeshResponse)(nil),       // 17: findpath.NavMeshResponse
+	(*NavPath)(nil),               // 18: findpath.NavPath
+	(*UploadMapRequest)(nil),      // 19: findpath.UploadMapRequest
+	(*UploadMapResponse)(nil),     // 20: findpath.UploadMapResponse
+	(*PathOnMapRequest)(nil),      // 21: findpath.PathOnMapRequest
+	(*DeleteMapRequest)(nil),      // 22: findpath.DeleteMapRequest
+	(*DeleteMapResponse)(nil),     // 23: findpath.DeleteMapResponse
+	(*ListMapsRequest)(nil),       // 24: findpath.ListMapsRequest
+	(*ListMapsResponse)(nil),      // 25: findpath.ListMapsResponse
+	(*MapInfo)(nil),               // 26: findpath.MapInfo
+	(*PatchMapRequest)(nil),       // 27: findpath.PatchMapRequest
+	(*CellPatch)(nil),             // 28: findpath.CellPatch
+	(*RectPatch)(nil),             // 29: findpath.RectPatch
+	(*PatchMapResponse)(nil),      // 30: findpath.PatchMapResponse
+	(*PathResponse)(nil),          // 31: findpath.PathResponse
+	(*PathStreamResponse)(nil),    // 32: findpath.PathStreamResponse
+	(*StreamPathRequest)(nil),     // 33: findpath.StreamPathRequest
+	(*StreamPathResult)(nil),      // 34: findpath.StreamPathResult
+	(*StreamError)(nil),           // 35: findpath.StreamError
+	(*Player)(nil),                // 36: findpath.Player
+	(*Path)(nil),                  // 37: findpath.Path
+	(*Route)(nil),                 // 38: findpath.Route
+	(*SearchStats)(nil),           // 39: findpath.SearchStats
+	(*Node)(nil),                  // 40: findpath.Node
+	nil,                           // 41: findpath.PathRequest.LayerWeightsEntry
+	nil,                           // 42: findpath.NavPlayer.MetadataEntry
+	nil,                           // 43: findpath.NavPath.MetadataEntry
+	nil,                           // 44: findpath.UploadMapRequest.LayerWeightsEntry
+	nil,                           // 45: findpath.Player.MetadataEntry
+	nil,                           // 46: findpath.Path.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 47: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 48: google.protobuf.Duration
 }
 var file_findpath_findpath_proto_depIdxs = []int32{
-	2, // 0: findpath.PathRequest.players:type_name -> findpath.Player
-	3, // 1: findpath.PathResponse.path:type_name -> findpath.Path
-	4, // 2: findpath.Player.start:type_name -> findpath.Node
-	4, // 3: findpath.Player.target:type_name -> findpath.Node
-	4, // 4: findpath.Path.steps:type_name -> findpath.Node
-	0, // 5: findpath.PathFinder.Path:input_type -> findpath.PathRequest
-	1, // 6: findpath.PathFinder.Path:output_type -> findpath.PathResponse
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	36, // 0: findpath.PathRequest.players:type_name -> findpath.Player
+	9,  // 1: findpath.PathRequest.cost_layers:type_name -> findpath.CostLayer
+	41, // 2: findpath.PathRequest.layer_weights:type_name -> findpath.PathRequest.LayerWeightsEntry
+	7,  // 3: findpath.PathRequest.portals:type_name -> findpath.Portal
+	8,  // 4: findpath.PathRequest.exits:type_name -> findpath.CellExits
+	0,  // 5: findpath.PathRequest.wrap:type_name -> findpath.Wrap
+	6,  // 6: findpath.PathRequest.connectors:type_name -> findpath.Connector
+	40, // 7: findpath.Connector.cells:type_name -> findpath.Node
+	40, // 8: findpath.Portal.from:type_name -> findpath.Node
+	40, // 9: findpath.Portal.to:type_name -> findpath.Node
+	40, // 10: findpath.CellExits.cell:type_name -> findpath.Node
+	2,  // 11: findpath.CellExits.allow:type_name -> findpath.Direction
+	10, // 12: findpath.CostLayer.cells:type_name -> findpath.CellCost
+	11, // 13: findpath.CostLayer.sources:type_name -> findpath.InfluenceSource
+	40, // 14: findpath.CellCost.cell:type_name -> findpath.Node
+	40, // 15: findpath.InfluenceSource.cell:type_name -> findpath.Node
+	3,  // 16: findpath.InfluenceSource.falloff:type_name -> findpath.InfluenceSource.Falloff
+	36, // 17: findpath.VolumeRequest.players:type_name -> findpath.Player
+	1,  // 18: findpath.VolumeRequest.connectivity:type_name -> findpath.Connectivity
+	0,  // 19: findpath.VolumeRequest.wrap:type_name -> findpath.Wrap
+	16, // 20: findpath.NavMeshRequest.vertices:type_name -> findpath.Point
+	14, // 21: findpath.NavMeshRequest.polygons:type_name -> findpath.Polygon
+	15, // 22: findpath.NavMeshRequest.players:type_name -> findpath.NavPlayer
+	16, // 23: findpath.NavPlayer.start:type_name -> findpath.Point
+	16, // 24: findpath.NavPlayer.target:type_name -> findpath.Point
+	42, // 25: findpath.NavPlayer.metadata:type_name -> findpath.NavPlayer.MetadataEntry
+	18, // 26: findpath.NavMeshResponse.paths:type_name -> findpath.NavPath
+	16, // 27: findpath.NavPath.points:type_name -> findpath.Point
+	39, // 28: findpath.NavPath.stats:type_name -> findpath.SearchStats
+	43, // 29: findpath.NavPath.metadata:type_name -> findpath.NavPath.MetadataEntry
+	6,  // 30: findpath.UploadMapRequest.connectors:type_name -> findpath.Connector
+	1,  // 31: findpath.UploadMapRequest.connectivity:type_name -> findpath.Connectivity
+	9,  // 32: findpath.UploadMapRequest.cost_layers:type_name -> findpath.CostLayer
+	44, // 33: findpath.UploadMapRequest.layer_weights:type_name -> findpath.UploadMapRequest.LayerWeightsEntry
+	7,  // 34: findpath.UploadMapRequest.portals:type_name -> findpath.Portal
+	8,  // 35: findpath.UploadMapRequest.exits:type_name -> findpath.CellExits
+	0,  // 36: findpath.UploadMapRequest.wrap:type_name -> findpath.Wrap
+	26, // 37: findpath.UploadMapResponse.map:type_name -> findpath.MapInfo
+	36, // 38: findpath.PathOnMapRequest.players:type_name -> findpath.Player
+	26, // 39: findpath.ListMapsResponse.maps:type_name -> findpath.MapInfo
+	47, // 40: findpath.MapInfo.uploaded_at:type_name -> google.protobuf.Timestamp
+	47, // 41: findpath.MapInfo.last_used_at:type_name -> google.protobuf.Timestamp
+	28, // 42: findpath.PatchMapRequest.cells:type_name -> findpath.CellPatch
+	29, // 43: findpath.PatchMapRequest.rects:type_name -> findpath.RectPatch
+	40, // 44: findpath.CellPatch.cell:type_name -> findpath.Node
+	40, // 45: findpath.RectPatch.from:type_name -> findpath.Node
+	40, // 46: findpath.RectPatch.to:type_name -> findpath.Node
+	26, // 47: findpath.PatchMapResponse.map:type_name -> findpath.MapInfo
+	37, // 48: findpath.PathResponse.path:type_name -> findpath.Path
+	37, // 49: findpath.PathStreamResponse.path:type_name -> findpath.Path
+	5,  // 50: findpath.StreamPathRequest.path:type_name -> findpath.PathRequest
+	21, // 51: findpath.StreamPathRequest.on_map:type_name -> findpath.PathOnMapRequest
+	37, // 52: findpath.StreamPathResult.path:type_name -> findpath.Path
+	35, // 53: findpath.StreamPathResult.error:type_name -> findpath.StreamError
+	40, // 54: findpath.Player.start:type_name -> findpath.Node
+	40, // 55: findpath.Player.target:type_name -> findpath.Node
+	2,  // 56: findpath.Player.heading:type_name -> findpath.Direction
+	45, // 57: findpath.Player.metadata:type_name -> findpath.Player.MetadataEntry
+	40, // 58: findpath.Path.steps:type_name -> findpath.Node
+	39, // 59: findpath.Path.stats:type_name -> findpath.SearchStats
+	38, // 60: findpath.Path.routes:type_name -> findpath.Route
+	4,  // 61: findpath.Path.status:type_name -> findpath.Path.Status
+	46, // 62: findpath.Path.metadata:type_name -> findpath.Path.MetadataEntry
+	40, // 63: findpath.Route.steps:type_name -> findpath.Node
+	48, // 64: findpath.SearchStats.duration:type_name -> google.protobuf.Duration
+	5,  // 65: findpath.PathFinder.Path:input_type -> findpath.PathRequest
+	5,  // 66: findpath.PathFinder.PathStream:input_type -> findpath.PathRequest
+	12, // 67: findpath.PathFinder.PathVolume:input_type -> findpath.VolumeRequest
+	13, // 68: findpath.PathFinder.PathNavMesh:input_type -> findpath.NavMeshRequest
+	19, // 69: findpath.PathFinder.UploadMap:input_type -> findpath.UploadMapRequest
+	21, // 70: findpath.PathFinder.PathOnMap:input_type -> findpath.PathOnMapRequest
+	22, // 71: findpath.PathFinder.DeleteMap:input_type -> findpath.DeleteMapRequest
+	24, // 72: findpath.PathFinder.ListMaps:input_type -> findpath.ListMapsRequest
+	27, // 73: findpath.PathFinder.PatchMap:input_type -> findpath.PatchMapRequest
+	33, // 74: findpath.PathFinder.StreamPaths:input_type -> findpath.StreamPathRequest
+	31, // 75: findpath.PathFinder.Path:output_type -> findpath.PathResponse
+	32, // 76: findpath.PathFinder.PathStream:output_type -> findpath.PathStreamResponse
+	31, // 77: findpath.PathFinder.PathVolume:output_type -> findpath.PathResponse
+	17, // 78: findpath.PathFinder.PathNavMesh:output_type -> findpath.NavMeshResponse
+	20, // 79: findpath.PathFinder.UploadMap:output_type -> findpath.UploadMapResponse
+	31, // 80: findpath.PathFinder.PathOnMap:output_type -> findpath.PathResponse
+	23, // 81: findpath.PathFinder.DeleteMap:output_type -> findpath.DeleteMapResponse
+	25, // 82: findpath.PathFinder.ListMaps:output_type -> findpath.ListMapsResponse
+	30, // 83: findpath.PathFinder.PatchMap:output_type -> findpath.PatchMapResponse
+	34, // 84: findpath.PathFinder.StreamPaths:output_type -> findpath.StreamPathResult
+	75, // [75:85] is the sub-list for method output_type
+	65, // [65:75] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
 }
 
 func init() { file_findpath_findpath_proto_init() }
@@ -363,18 +3108,23 @@ func file_findpath_findpath_proto_init() {
 	if File_findpath_findpath_proto != nil {
 		return
 	}
+	file_findpath_findpath_proto_msgTypes[28].OneofWrappers = []any{
+		(*StreamPathRequest_Path)(nil),
+		(*StreamPathRequest_OnMap)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_findpath_findpath_proto_rawDesc), len(file_findpath_findpath_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      5,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_findpath_findpath_proto_goTypes,
 		DependencyIndexes: file_findpath_findpath_proto_depIdxs,
+		EnumInfos:         file_findpath_findpath_proto_enumTypes,
 		MessageInfos:      file_findpath_findpath_proto_msgTypes,
 	}.Build()
 	File_findpath_findpath_proto = out.File
@@ -19,7 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PathFinder_Path_FullMethodName = "/findpath.PathFinder/Path"
+	PathFinder_Path_FullMethodName        = "/findpath.PathFinder/Path"
+	PathFinder_PathStream_FullMethodName  = "/findpath.PathFinder/PathStream"
+	PathFinder_PathVolume_FullMethodName  = "/findpath.PathFinder/PathVolume"
+	PathFinder_PathNavMesh_FullMethodName = "/findpath.PathFinder/PathNavMesh"
+	PathFinder_UploadMap_FullMethodName   = "/findpath.PathFinder/UploadMap"
+	PathFinder_PathOnMap_FullMethodName   = "/findpath.PathFinder/PathOnMap"
+	PathFinder_DeleteMap_FullMethodName   = "/findpath.PathFinder/DeleteMap"
+	PathFinder_ListMaps_FullMethodName    = "/findpath.PathFinder/ListMaps"
+	PathFinder_PatchMap_FullMethodName    = "/findpath.PathFinder/PatchMap"
+	PathFinder_StreamPaths_FullMethodName = "/findpath.PathFinder/StreamPaths"
 )
 
 // PathFinderClient is the client API for PathFinder service.
@@ -27,6 +36,22 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PathFinderClient interface {
 	Path(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*PathResponse, error)
+	// PathStream is Path with every player's path sent as soon as its search is done.
+	PathStream(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathStreamResponse], error)
+	PathVolume(ctx context.Context, in *VolumeRequest, opts ...grpc.CallOption) (*PathResponse, error)
+	PathNavMesh(ctx context.Context, in *NavMeshRequest, opts ...grpc.CallOption) (*NavMeshResponse, error)
+	// UploadMap stores a map on the server, so that it is sent once and searched many
+	// times with PathOnMap. Least recently used maps are evicted when the server is full.
+	UploadMap(ctx context.Context, in *UploadMapRequest, opts ...grpc.CallOption) (*UploadMapResponse, error)
+	PathOnMap(ctx context.Context, in *PathOnMapRequest, opts ...grpc.CallOption) (*PathResponse, error)
+	DeleteMap(ctx context.Context, in *DeleteMapRequest, opts ...grpc.CallOption) (*DeleteMapResponse, error)
+	ListMaps(ctx context.Context, in *ListMapsRequest, opts ...grpc.CallOption) (*ListMapsResponse, error)
+	// PatchMap changes cells of an uploaded map atomically and bumps its version.
+	PatchMap(ctx context.Context, in *PatchMapRequest, opts ...grpc.CallOption) (*PatchMapResponse, error)
+	// StreamPaths keeps one stream open for many queries. Each query carries a correlation
+	// id, and its results come back per player as soon as each search is done, in any order,
+	// tagged with that id.
+	StreamPaths(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamPathRequest, StreamPathResult], error)
 }
 
 type pathFinderClient struct {
@@ -47,11 +72,129 @@ func (c *pathFinderClient) Path(ctx context.Context, in *PathRequest, opts ...gr
 	return out, nil
 }
 
+func (c *pathFinderClient) PathStream(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PathFinder_ServiceDesc.Streams[0], PathFinder_PathStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PathRequest, PathStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_PathStreamClient = grpc.ServerStreamingClient[PathStreamResponse]
+
+func (c *pathFinderClient) PathVolume(ctx context.Context, in *VolumeRequest, opts ...grpc.CallOption) (*PathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResponse)
+	err := c.cc.Invoke(ctx, PathFinder_PathVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) PathNavMesh(ctx context.Context, in *NavMeshRequest, opts ...grpc.CallOption) (*NavMeshResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NavMeshResponse)
+	err := c.cc.Invoke(ctx, PathFinder_PathNavMesh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) UploadMap(ctx context.Context, in *UploadMapRequest, opts ...grpc.CallOption) (*UploadMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadMapResponse)
+	err := c.cc.Invoke(ctx, PathFinder_UploadMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) PathOnMap(ctx context.Context, in *PathOnMapRequest, opts ...grpc.CallOption) (*PathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResponse)
+	err := c.cc.Invoke(ctx, PathFinder_PathOnMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) DeleteMap(ctx context.Context, in *DeleteMapRequest, opts ...grpc.CallOption) (*DeleteMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMapResponse)
+	err := c.cc.Invoke(ctx, PathFinder_DeleteMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) ListMaps(ctx context.Context, in *ListMapsRequest, opts ...grpc.CallOption) (*ListMapsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMapsResponse)
+	err := c.cc.Invoke(ctx, PathFinder_ListMaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) PatchMap(ctx context.Context, in *PatchMapRequest, opts ...grpc.CallOption) (*PatchMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatchMapResponse)
+	err := c.cc.Invoke(ctx, PathFinder_PatchMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) StreamPaths(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamPathRequest, StreamPathResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PathFinder_ServiceDesc.Streams[1], PathFinder_StreamPaths_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPathRequest, StreamPathResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_StreamPathsClient = grpc.BidiStreamingClient[StreamPathRequest, StreamPathResult]
+
 // PathFinderServer is the server API for PathFinder service.
 // All implementations must embed UnimplementedPathFinderServer
 // for forward compatibility.
 type PathFinderServer interface {
 	Path(context.Context, *PathRequest) (*PathResponse, error)
+	// PathStream is Path with every player's path sent as soon as its search is done.
+	PathStream(*PathRequest, grpc.ServerStreamingServer[PathStreamResponse]) error
+	PathVolume(context.Context, *VolumeRequest) (*PathResponse, error)
+	PathNavMesh(context.Context, *NavMeshRequest) (*NavMeshResponse, error)
+	// UploadMap stores a map on the server, so that it is sent once and searched many
+	// times with PathOnMap. Least recently used maps are evicted when the server is full.
+	UploadMap(context.Context, *UploadMapRequest) (*UploadMapResponse, error)
+	PathOnMap(context.Context, *PathOnMapRequest) (*PathResponse, error)
+	DeleteMap(context.Context, *DeleteMapRequest) (*DeleteMapResponse, error)
+	ListMaps(context.Context, *ListMapsRequest) (*ListMapsResponse, error)
+	// PatchMap changes cells of an uploaded map atomically and bumps its version.
+	PatchMap(context.Context, *PatchMapRequest) (*PatchMapResponse, error)
+	// StreamPaths keeps one stream open for many queries. Each query carries a correlation
+	// id, and its results come back per player as soon as each search is done, in any order,
+	// tagged with that id.
+	StreamPaths(grpc.BidiStreamingServer[StreamPathRequest, StreamPathResult]) error
 	mustEmbedUnimplementedPathFinderServer()
 }
 
@@ -65,6 +208,33 @@ type UnimplementedPathFinderServer struct{}
 func (UnimplementedPathFinderServer) Path(context.Context, *PathRequest) (*PathResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Path not implemented")
 }
+func (UnimplementedPathFinderServer) PathStream(*PathRequest, grpc.ServerStreamingServer[PathStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PathStream not implemented")
+}
+func (UnimplementedPathFinderServer) PathVolume(context.Context, *VolumeRequest) (*PathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PathVolume not implemented")
+}
+func (UnimplementedPathFinderServer) PathNavMesh(context.Context, *NavMeshRequest) (*NavMeshResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PathNavMesh not implemented")
+}
+func (UnimplementedPathFinderServer) UploadMap(context.Context, *UploadMapRequest) (*UploadMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadMap not implemented")
+}
+func (UnimplementedPathFinderServer) PathOnMap(context.Context, *PathOnMapRequest) (*PathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PathOnMap not implemented")
+}
+func (UnimplementedPathFinderServer) DeleteMap(context.Context, *DeleteMapRequest) (*DeleteMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMap not implemented")
+}
+func (UnimplementedPathFinderServer) ListMaps(context.Context, *ListMapsRequest) (*ListMapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaps not implemented")
+}
+func (UnimplementedPathFinderServer) PatchMap(context.Context, *PatchMapRequest) (*PatchMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchMap not implemented")
+}
+func (UnimplementedPathFinderServer) StreamPaths(grpc.BidiStreamingServer[StreamPathRequest, StreamPathResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPaths not implemented")
+}
 func (UnimplementedPathFinderServer) mustEmbedUnimplementedPathFinderServer() {}
 func (UnimplementedPathFinderServer) testEmbeddedByValue()                    {}
 
@@ -104,6 +274,150 @@ func _PathFinder_Path_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PathFinder_PathStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PathFinderServer).PathStream(m, &grpc.GenericServerStream[PathRequest, PathStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_PathStreamServer = grpc.ServerStreamingServer[PathStreamResponse]
+
+func _PathFinder_PathVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).PathVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_PathVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).PathVolume(ctx, req.(*VolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_PathNavMesh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NavMeshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).PathNavMesh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_PathNavMesh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).PathNavMesh(ctx, req.(*NavMeshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_UploadMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).UploadMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_UploadMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).UploadMap(ctx, req.(*UploadMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_PathOnMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathOnMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).PathOnMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_PathOnMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).PathOnMap(ctx, req.(*PathOnMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_DeleteMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).DeleteMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_DeleteMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).DeleteMap(ctx, req.(*DeleteMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_ListMaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).ListMaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_ListMaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).ListMaps(ctx, req.(*ListMapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_PatchMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).PatchMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_PatchMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).PatchMap(ctx, req.(*PatchMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_StreamPaths_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PathFinderServer).StreamPaths(&grpc.GenericServerStream[StreamPathRequest, StreamPathResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_StreamPathsServer = grpc.BidiStreamingServer[StreamPathRequest, StreamPathResult]
+
 // PathFinder_ServiceDesc is the grpc.ServiceDesc for PathFinder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +429,47 @@ var PathFinder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Path",
 			Handler:    _PathFinder_Path_Handler,
 		},
+		{
+			MethodName: "PathVolume",
+			Handler:    _PathFinder_PathVolume_Handler,
+		},
+		{
+			MethodName: "PathNavMesh",
+			Handler:    _PathFinder_PathNavMesh_Handler,
+		},
+		{
+			MethodName: "UploadMap",
+			Handler:    _PathFinder_UploadMap_Handler,
+		},
+		{
+			MethodName: "PathOnMap",
+			Handler:    _PathFinder_PathOnMap_Handler,
+		},
+		{
+			MethodName: "DeleteMap",
+			Handler:    _PathFinder_DeleteMap_Handler,
+		},
+		{
+			MethodName: "ListMaps",
+			Handler:    _PathFinder_ListMaps_Handler,
+		},
+		{
+			MethodName: "PatchMap",
+			Handler:    _PathFinder_PatchMap_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PathStream",
+			Handler:       _PathFinder_PathStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPaths",
+			Handler:       _PathFinder_StreamPaths_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "findpath/findpath.proto",
 }
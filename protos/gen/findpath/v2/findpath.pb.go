@@ -0,0 +1,1944 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: findpath/v2/findpath.proto
+
+// Version 2 lets clients choose how the server searches: algorithm, heuristic, movement and
+// limits. Version 1 is served next to it unchanged.
+
+package findpathv2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Algorithm int32
+
+const (
+	Algorithm_ALGORITHM_UNSPECIFIED Algorithm = 0 // A*
+	Algorithm_ALGORITHM_A_STAR      Algorithm = 1
+	Algorithm_ALGORITHM_DIJKSTRA    Algorithm = 2
+	Algorithm_ALGORITHM_BFS         Algorithm = 3 // fewest steps, ignores costs and turn rules
+	Algorithm_ALGORITHM_JPS         Algorithm = 4 // A* jumping over open space with MOVEMENT_VOXEL_26, plain A* otherwise
+)
+
+// Enum value maps for Algorithm.
+var (
+	Algorithm_name = map[int32]string{
+		0: "ALGORITHM_UNSPECIFIED",
+		1: "ALGORITHM_A_STAR",
+		2: "ALGORITHM_DIJKSTRA",
+		3: "ALGORITHM_BFS",
+		4: "ALGORITHM_JPS",
+	}
+	Algorithm_value = map[string]int32{
+		"ALGORITHM_UNSPECIFIED": 0,
+		"ALGORITHM_A_STAR":      1,
+		"ALGORITHM_DIJKSTRA":    2,
+		"ALGORITHM_BFS":         3,
+		"ALGORITHM_JPS":         4,
+	}
+)
+
+func (x Algorithm) Enum() *Algorithm {
+	p := new(Algorithm)
+	*p = x
+	return p
+}
+
+func (x Algorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Algorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[0].Descriptor()
+}
+
+func (Algorithm) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[0]
+}
+
+func (x Algorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Algorithm.Descriptor instead.
+func (Algorithm) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{0}
+}
+
+type Heuristic int32
+
+const (
+	Heuristic_HEURISTIC_UNSPECIFIED Heuristic = 0 // distance
+	Heuristic_HEURISTIC_DISTANCE    Heuristic = 1 // least steps to the target, portals included
+	Heuristic_HEURISTIC_NONE        Heuristic = 2 // A* expands cells in the same order as Dijkstra
+)
+
+// Enum value maps for Heuristic.
+var (
+	Heuristic_name = map[int32]string{
+		0: "HEURISTIC_UNSPECIFIED",
+		1: "HEURISTIC_DISTANCE",
+		2: "HEURISTIC_NONE",
+	}
+	Heuristic_value = map[string]int32{
+		"HEURISTIC_UNSPECIFIED": 0,
+		"HEURISTIC_DISTANCE":    1,
+		"HEURISTIC_NONE":        2,
+	}
+)
+
+func (x Heuristic) Enum() *Heuristic {
+	p := new(Heuristic)
+	*p = x
+	return p
+}
+
+func (x Heuristic) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Heuristic) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[1].Descriptor()
+}
+
+func (Heuristic) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[1]
+}
+
+func (x Heuristic) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Heuristic.Descriptor instead.
+func (Heuristic) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{1}
+}
+
+// Movement is how players step between cells. Voxel moves cost one step whatever the number
+// of axes they change, turn rules and exits need grid movement.
+type Movement int32
+
+const (
+	Movement_MOVEMENT_UNSPECIFIED Movement = 0 // grid
+	Movement_MOVEMENT_GRID        Movement = 1 // 4 directions within a level, levels joined by connectors
+	Movement_MOVEMENT_VOXEL_6     Movement = 2 // one axis per move
+	Movement_MOVEMENT_VOXEL_18    Movement = 3 // up to two axes per move, 8 directions on a single level
+	Movement_MOVEMENT_VOXEL_26    Movement = 4 // up to three axes per move
+)
+
+// Enum value maps for Movement.
+var (
+	Movement_name = map[int32]string{
+		0: "MOVEMENT_UNSPECIFIED",
+		1: "MOVEMENT_GRID",
+		2: "MOVEMENT_VOXEL_6",
+		3: "MOVEMENT_VOXEL_18",
+		4: "MOVEMENT_VOXEL_26",
+	}
+	Movement_value = map[string]int32{
+		"MOVEMENT_UNSPECIFIED": 0,
+		"MOVEMENT_GRID":        1,
+		"MOVEMENT_VOXEL_6":     2,
+		"MOVEMENT_VOXEL_18":    3,
+		"MOVEMENT_VOXEL_26":    4,
+	}
+)
+
+func (x Movement) Enum() *Movement {
+	p := new(Movement)
+	*p = x
+	return p
+}
+
+func (x Movement) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Movement) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[2].Descriptor()
+}
+
+func (Movement) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[2]
+}
+
+func (x Movement) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Movement.Descriptor instead.
+func (Movement) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{2}
+}
+
+// Wrap makes map edges connect to the opposite side.
+type Wrap int32
+
+const (
+	Wrap_WRAP_NONE       Wrap = 0
+	Wrap_WRAP_HORIZONTAL Wrap = 1 // x = 0 and x = width-1 are adjacent
+	Wrap_WRAP_VERTICAL   Wrap = 2 // y = 0 and y = height-1 are adjacent
+	Wrap_WRAP_BOTH       Wrap = 3
+)
+
+// Enum value maps for Wrap.
+var (
+	Wrap_name = map[int32]string{
+		0: "WRAP_NONE",
+		1: "WRAP_HORIZONTAL",
+		2: "WRAP_VERTICAL",
+		3: "WRAP_BOTH",
+	}
+	Wrap_value = map[string]int32{
+		"WRAP_NONE":       0,
+		"WRAP_HORIZONTAL": 1,
+		"WRAP_VERTICAL":   2,
+		"WRAP_BOTH":       3,
+	}
+)
+
+func (x Wrap) Enum() *Wrap {
+	p := new(Wrap)
+	*p = x
+	return p
+}
+
+func (x Wrap) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Wrap) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[3].Descriptor()
+}
+
+func (Wrap) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[3]
+}
+
+func (x Wrap) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Wrap.Descriptor instead.
+func (Wrap) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{3}
+}
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_NONE  Direction = 0
+	Direction_DIRECTION_UP    Direction = 1 // towards y = 0
+	Direction_DIRECTION_DOWN  Direction = 2
+	Direction_DIRECTION_LEFT  Direction = 3 // towards x = 0
+	Direction_DIRECTION_RIGHT Direction = 4
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_NONE",
+		1: "DIRECTION_UP",
+		2: "DIRECTION_DOWN",
+		3: "DIRECTION_LEFT",
+		4: "DIRECTION_RIGHT",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_NONE":  0,
+		"DIRECTION_UP":    1,
+		"DIRECTION_DOWN":  2,
+		"DIRECTION_LEFT":  3,
+		"DIRECTION_RIGHT": 4,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[4].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[4]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{4}
+}
+
+type InfluenceSource_Falloff int32
+
+const (
+	InfluenceSource_LINEAR    InfluenceSource_Falloff = 0
+	InfluenceSource_CONSTANT  InfluenceSource_Falloff = 1
+	InfluenceSource_QUADRATIC InfluenceSource_Falloff = 2
+)
+
+// Enum value maps for InfluenceSource_Falloff.
+var (
+	InfluenceSource_Falloff_name = map[int32]string{
+		0: "LINEAR",
+		1: "CONSTANT",
+		2: "QUADRATIC",
+	}
+	InfluenceSource_Falloff_value = map[string]int32{
+		"LINEAR":    0,
+		"CONSTANT":  1,
+		"QUADRATIC": 2,
+	}
+)
+
+func (x InfluenceSource_Falloff) Enum() *InfluenceSource_Falloff {
+	p := new(InfluenceSource_Falloff)
+	*p = x
+	return p
+}
+
+func (x InfluenceSource_Falloff) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InfluenceSource_Falloff) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[5].Descriptor()
+}
+
+func (InfluenceSource_Falloff) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[5]
+}
+
+func (x InfluenceSource_Falloff) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InfluenceSource_Falloff.Descriptor instead.
+func (InfluenceSource_Falloff) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{12, 0}
+}
+
+// Status tells what came of the search of one player, a bad player doesn't fail the others.
+type Path_Status int32
+
+const (
+	Path_STATUS_UNSPECIFIED Path_Status = 0
+	Path_OK                 Path_Status = 1
+	Path_NO_PATH            Path_Status = 2
+	Path_INVALID_START      Path_Status = 3 // off the map, on a blocked cell or with an unknown heading
+	Path_INVALID_TARGET     Path_Status = 4 // off the map or on a blocked cell
+	Path_CANCELLED          Path_Status = 5
+	Path_BUDGET_EXCEEDED    Path_Status = 6
+)
+
+// Enum value maps for Path_Status.
+var (
+	Path_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "OK",
+		2: "NO_PATH",
+		3: "INVALID_START",
+		4: "INVALID_TARGET",
+		5: "CANCELLED",
+		6: "BUDGET_EXCEEDED",
+	}
+	Path_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"OK":                 1,
+		"NO_PATH":            2,
+		"INVALID_START":      3,
+		"INVALID_TARGET":     4,
+		"CANCELLED":          5,
+		"BUDGET_EXCEEDED":    6,
+	}
+)
+
+func (x Path_Status) Enum() *Path_Status {
+	p := new(Path_Status)
+	*p = x
+	return p
+}
+
+func (x Path_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Path_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_findpath_v2_findpath_proto_enumTypes[6].Descriptor()
+}
+
+func (Path_Status) Type() protoreflect.EnumType {
+	return &file_findpath_v2_findpath_proto_enumTypes[6]
+}
+
+func (x Path_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Path_Status.Descriptor instead.
+func (Path_Status) EnumDescriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type PathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grid          *Grid                  `protobuf:"bytes,1,opt,name=grid,proto3" json:"grid,omitempty"`
+	Players       []*Player              `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	Options       *SearchOptions         `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathRequest) Reset() {
+	*x = PathRequest{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathRequest) ProtoMessage() {}
+
+func (x *PathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
+func (*PathRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PathRequest) GetGrid() *Grid {
+	if x != nil {
+		return x.Grid
+	}
+	return nil
+}
+
+func (x *PathRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PathRequest) GetOptions() *SearchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PathOnMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Players       []*Player              `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	Options       *SearchOptions         `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"` // movement is fixed by the upload and must be unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathOnMapRequest) Reset() {
+	*x = PathOnMapRequest{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathOnMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathOnMapRequest) ProtoMessage() {}
+
+func (x *PathOnMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathOnMapRequest.ProtoReflect.Descriptor instead.
+func (*PathOnMapRequest) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PathOnMapRequest) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *PathOnMapRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PathOnMapRequest) GetOptions() *SearchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*Path                `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	MapVersion    uint64                 `protobuf:"varint,2,opt,name=map_version,json=mapVersion,proto3" json:"map_version,omitempty"` // version of the map PathOnMap searched
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathResponse) Reset() {
+	*x = PathResponse{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathResponse) ProtoMessage() {}
+
+func (x *PathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathResponse.ProtoReflect.Descriptor instead.
+func (*PathResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PathResponse) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *PathResponse) GetMapVersion() uint64 {
+	if x != nil {
+		return x.MapVersion
+	}
+	return 0
+}
+
+type PathStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          *Path                  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // player_id tells which player of the request it belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathStreamResponse) Reset() {
+	*x = PathStreamResponse{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathStreamResponse) ProtoMessage() {}
+
+func (x *PathStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathStreamResponse.ProtoReflect.Descriptor instead.
+func (*PathStreamResponse) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PathStreamResponse) GetPath() *Path {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+// SearchOptions are checked before searching, invalid ones fail with INVALID_ARGUMENT.
+type SearchOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm       Algorithm              `protobuf:"varint,1,opt,name=algorithm,proto3,enum=findpath.v2.Algorithm" json:"algorithm,omitempty"`
+	Heuristic       Heuristic              `protobuf:"varint,2,opt,name=heuristic,proto3,enum=findpath.v2.Heuristic" json:"heuristic,omitempty"`          // A* and JPS only
+	HeuristicWeight float64                `protobuf:"fixed64,3,opt,name=heuristic_weight,json=heuristicWeight,proto3" json:"heuristic_weight,omitempty"` // A* and JPS only, above 1 is faster but paths may not be the cheapest, 0 means 1
+	Movement        Movement               `protobuf:"varint,4,opt,name=movement,proto3,enum=findpath.v2.Movement" json:"movement,omitempty"`
+	Limits          *Limits                `protobuf:"bytes,5,opt,name=limits,proto3" json:"limits,omitempty"`
+	Stats           bool                   `protobuf:"varint,6,opt,name=stats,proto3" json:"stats,omitempty"`                                   // fill Path.stats
+	PartialPaths    bool                   `protobuf:"varint,7,opt,name=partial_paths,json=partialPaths,proto3" json:"partial_paths,omitempty"` // A* and JPS only, a path that isn't found leads to the cell closest to the target
+	KPaths          int32                  `protobuf:"varint,8,opt,name=k_paths,json=kPaths,proto3" json:"k_paths,omitempty"`                   // > 1 returns up to k ranked routes per player, whatever the algorithm
+	MaxOverlap      float64                `protobuf:"fixed64,9,opt,name=max_overlap,json=maxOverlap,proto3" json:"max_overlap,omitempty"`      // skip routes sharing more than this share (0..1) of cells with a better one, 0 - off
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchOptions) Reset() {
+	*x = SearchOptions{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOptions) ProtoMessage() {}
+
+func (x *SearchOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOptions.ProtoReflect.Descriptor instead.
+func (*SearchOptions) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchOptions) GetAlgorithm() Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return Algorithm_ALGORITHM_UNSPECIFIED
+}
+
+func (x *SearchOptions) GetHeuristic() Heuristic {
+	if x != nil {
+		return x.Heuristic
+	}
+	return Heuristic_HEURISTIC_UNSPECIFIED
+}
+
+func (x *SearchOptions) GetHeuristicWeight() float64 {
+	if x != nil {
+		return x.HeuristicWeight
+	}
+	return 0
+}
+
+func (x *SearchOptions) GetMovement() Movement {
+	if x != nil {
+		return x.Movement
+	}
+	return Movement_MOVEMENT_UNSPECIFIED
+}
+
+func (x *SearchOptions) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *SearchOptions) GetStats() bool {
+	if x != nil {
+		return x.Stats
+	}
+	return false
+}
+
+func (x *SearchOptions) GetPartialPaths() bool {
+	if x != nil {
+		return x.PartialPaths
+	}
+	return false
+}
+
+func (x *SearchOptions) GetKPaths() int32 {
+	if x != nil {
+		return x.KPaths
+	}
+	return 0
+}
+
+func (x *SearchOptions) GetMaxOverlap() float64 {
+	if x != nil {
+		return x.MaxOverlap
+	}
+	return 0
+}
+
+type Limits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxExpansions int64                  `protobuf:"varint,1,opt,name=max_expansions,json=maxExpansions,proto3" json:"max_expansions,omitempty"` // nodes expanded per player, 0 - no limit
+	MaxMemory     int64                  `protobuf:"varint,2,opt,name=max_memory,json=maxMemory,proto3" json:"max_memory,omitempty"`             // approximate bytes per player, 0 - no limit
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                                   // for the whole request, unset - no limit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Limits) GetMaxExpansions() int64 {
+	if x != nil {
+		return x.MaxExpansions
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxMemory() int64 {
+	if x != nil {
+		return x.MaxMemory
+	}
+	return 0
+}
+
+func (x *Limits) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Grid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Depth         int32                  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`        // number of levels stacked in cells, 0 means 1
+	Cells         []int32                `protobuf:"varint,4,rep,packed,name=cells,proto3" json:"cells,omitempty"` // flat array, level by level
+	CostLayers    []*CostLayer           `protobuf:"bytes,5,rep,name=cost_layers,json=costLayers,proto3" json:"cost_layers,omitempty"`
+	LayerWeights  map[string]float64     `protobuf:"bytes,6,rep,name=layer_weights,json=layerWeights,proto3" json:"layer_weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // by layer name, missing layers weigh 1
+	TurnCost      int32                  `protobuf:"varint,7,opt,name=turn_cost,json=turnCost,proto3" json:"turn_cost,omitempty"`                                                                                        // added for every 90° change of direction
+	MaxTurns      int32                  `protobuf:"varint,8,opt,name=max_turns,json=maxTurns,proto3" json:"max_turns,omitempty"`                                                                                        // max 90° direction changes per path, 0 - no limit
+	Portals       []*Portal              `protobuf:"bytes,9,rep,name=portals,proto3" json:"portals,omitempty"`
+	Exits         []*CellExits           `protobuf:"bytes,10,rep,name=exits,proto3" json:"exits,omitempty"` // cells that can only be left in some directions
+	Wrap          Wrap                   `protobuf:"varint,11,opt,name=wrap,proto3,enum=findpath.v2.Wrap" json:"wrap,omitempty"`
+	Connectors    []*Connector           `protobuf:"bytes,12,rep,name=connectors,proto3" json:"connectors,omitempty"` // stairs and elevators between levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Grid) Reset() {
+	*x = Grid{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Grid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grid) ProtoMessage() {}
+
+func (x *Grid) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grid.ProtoReflect.Descriptor instead.
+func (*Grid) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Grid) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Grid) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Grid) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Grid) GetCells() []int32 {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Grid) GetCostLayers() []*CostLayer {
+	if x != nil {
+		return x.CostLayers
+	}
+	return nil
+}
+
+func (x *Grid) GetLayerWeights() map[string]float64 {
+	if x != nil {
+		return x.LayerWeights
+	}
+	return nil
+}
+
+func (x *Grid) GetTurnCost() int32 {
+	if x != nil {
+		return x.TurnCost
+	}
+	return 0
+}
+
+func (x *Grid) GetMaxTurns() int32 {
+	if x != nil {
+		return x.MaxTurns
+	}
+	return 0
+}
+
+func (x *Grid) GetPortals() []*Portal {
+	if x != nil {
+		return x.Portals
+	}
+	return nil
+}
+
+func (x *Grid) GetExits() []*CellExits {
+	if x != nil {
+		return x.Exits
+	}
+	return nil
+}
+
+func (x *Grid) GetWrap() Wrap {
+	if x != nil {
+		return x.Wrap
+	}
+	return Wrap_WRAP_NONE
+}
+
+func (x *Grid) GetConnectors() []*Connector {
+	if x != nil {
+		return x.Connectors
+	}
+	return nil
+}
+
+// Connector links cells on different levels. Each pair of its cells is connected both ways,
+// cost is paid per level travelled.
+type Connector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // informational, e.g. "stairs" or "elevator"
+	Cells         []*Node                `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	Cost          int32                  `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connector) Reset() {
+	*x = Connector{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connector) ProtoMessage() {}
+
+func (x *Connector) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connector.ProtoReflect.Descriptor instead.
+func (*Connector) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Connector) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Connector) GetCells() []*Node {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Connector) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+// Portal is a directed edge between any two cells: a teleport pad, a ladder or a door.
+type Portal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Node                  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Node                  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Cost          int32                  `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Portal) Reset() {
+	*x = Portal{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Portal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Portal) ProtoMessage() {}
+
+func (x *Portal) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Portal.ProtoReflect.Descriptor instead.
+func (*Portal) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Portal) GetFrom() *Node {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Portal) GetTo() *Node {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Portal) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+// CellExits limits the directions a cell can be left in, portals are not affected.
+type CellExits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          *Node                  `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Allow         []Direction            `protobuf:"varint,2,rep,packed,name=allow,proto3,enum=findpath.v2.Direction" json:"allow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellExits) Reset() {
+	*x = CellExits{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellExits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellExits) ProtoMessage() {}
+
+func (x *CellExits) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellExits.ProtoReflect.Descriptor instead.
+func (*CellExits) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CellExits) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellExits) GetAllow() []Direction {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+// CostLayer makes cells more expensive to enter without blocking them.
+type CostLayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cells         []*CellCost            `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`         // sparse
+	Dense         []int32                `protobuf:"varint,3,rep,packed,name=dense,proto3" json:"dense,omitempty"` // flat depth*width*height array
+	Sources       []*InfluenceSource     `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`     // radial
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostLayer) Reset() {
+	*x = CostLayer{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostLayer) ProtoMessage() {}
+
+func (x *CostLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostLayer.ProtoReflect.Descriptor instead.
+func (*CostLayer) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CostLayer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CostLayer) GetCells() []*CellCost {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *CostLayer) GetDense() []int32 {
+	if x != nil {
+		return x.Dense
+	}
+	return nil
+}
+
+func (x *CostLayer) GetSources() []*InfluenceSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type CellCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          *Node                  `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Cost          int32                  `protobuf:"varint,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellCost) Reset() {
+	*x = CellCost{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellCost) ProtoMessage() {}
+
+func (x *CellCost) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellCost.ProtoReflect.Descriptor instead.
+func (*CellCost) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CellCost) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellCost) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type InfluenceSource struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Cell          *Node                   `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Strength      int32                   `protobuf:"varint,2,opt,name=strength,proto3" json:"strength,omitempty"`
+	Radius        int32                   `protobuf:"varint,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	Falloff       InfluenceSource_Falloff `protobuf:"varint,4,opt,name=falloff,proto3,enum=findpath.v2.InfluenceSource_Falloff" json:"falloff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfluenceSource) Reset() {
+	*x = InfluenceSource{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfluenceSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfluenceSource) ProtoMessage() {}
+
+func (x *InfluenceSource) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfluenceSource.ProtoReflect.Descriptor instead.
+func (*InfluenceSource) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InfluenceSource) GetCell() *Node {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *InfluenceSource) GetStrength() int32 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *InfluenceSource) GetRadius() int32 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *InfluenceSource) GetFalloff() InfluenceSource_Falloff {
+	if x != nil {
+		return x.Falloff
+	}
+	return InfluenceSource_LINEAR
+}
+
+type Player struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *Node                  `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Target        *Node                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Heading       Direction              `protobuf:"varint,3,opt,name=heading,proto3,enum=findpath.v2.Direction" json:"heading,omitempty"`                                                 // initial heading, only used with turn rules
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // returned as Path.player_id, the index of the player when empty
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // opaque to the server, returned as Path.metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Player) GetStart() *Node {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Player) GetTarget() *Node {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Player) GetHeading() Direction {
+	if x != nil {
+		return x.Heading
+	}
+	return Direction_DIRECTION_NONE
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Path struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Steps         []*Node                `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Found         bool                   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	Stats         *SearchStats           `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`      // only with SearchOptions.stats
+	Routes        []*Route               `protobuf:"bytes,5,rep,name=routes,proto3" json:"routes,omitempty"`    // ranked alternatives, best first
+	Partial       bool                   `protobuf:"varint,6,opt,name=partial,proto3" json:"partial,omitempty"` // not found, steps lead to the cell closest to the target
+	Status        Path_Status            `protobuf:"varint,7,opt,name=status,proto3,enum=findpath.v2.Path_Status" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`                                                                             // why the status isn't OK
+	Metadata      map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // of the player, as given
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Path) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Path) GetSteps() []*Node {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Path) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *Path) GetStats() *SearchStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Path) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *Path) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *Path) GetStatus() Path_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Path_STATUS_UNSPECIFIED
+}
+
+func (x *Path) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Path) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Route struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*Node                `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	Cost          int32                  `protobuf:"varint,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Route) GetSteps() []*Node {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Route) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type SearchStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Cost           int32                  `protobuf:"varint,1,opt,name=cost,proto3" json:"cost,omitempty"` // total cost of the path or of the partial one, 0 without either
+	NodesExpanded  int64                  `protobuf:"varint,2,opt,name=nodes_expanded,json=nodesExpanded,proto3" json:"nodes_expanded,omitempty"`
+	NodesGenerated int64                  `protobuf:"varint,3,opt,name=nodes_generated,json=nodesGenerated,proto3" json:"nodes_generated,omitempty"`
+	PeakOpen       int64                  `protobuf:"varint,4,opt,name=peak_open,json=peakOpen,proto3" json:"peak_open,omitempty"` // max size of the open list
+	Duration       *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchStats) Reset() {
+	*x = SearchStats{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStats) ProtoMessage() {}
+
+func (x *SearchStats) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStats.ProtoReflect.Descriptor instead.
+func (*SearchStats) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchStats) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *SearchStats) GetNodesExpanded() int64 {
+	if x != nil {
+		return x.NodesExpanded
+	}
+	return 0
+}
+
+func (x *SearchStats) GetNodesGenerated() int64 {
+	if x != nil {
+		return x.NodesGenerated
+	}
+	return 0
+}
+
+func (x *SearchStats) GetPeakOpen() int64 {
+	if x != nil {
+		return x.PeakOpen
+	}
+	return 0
+}
+
+func (x *SearchStats) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type Node struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Y             int32                  `protobuf:"varint,1,opt,name=y,proto3" json:"y,omitempty"`
+	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Z             int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"` // level, 0 on single level maps
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_findpath_v2_findpath_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_findpath_v2_findpath_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_findpath_v2_findpath_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Node) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Node) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Node) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+var File_findpath_v2_findpath_proto protoreflect.FileDescriptor
+
+const file_findpath_v2_findpath_proto_rawDesc = "" +
+	"\n" +
+	"\x1afindpath/v2/findpath.proto\x12\vfindpath.v2\x1a\x1egoogle/protobuf/duration.proto\"\x99\x01\n" +
+	"\vPathRequest\x12%\n" +
+	"\x04grid\x18\x01 \x01(\v2\x11.findpath.v2.GridR\x04grid\x12-\n" +
+	"\aplayers\x18\x02 \x03(\v2\x13.findpath.v2.PlayerR\aplayers\x124\n" +
+	"\aoptions\x18\x03 \x01(\v2\x1a.findpath.v2.SearchOptionsR\aoptions\"\x8e\x01\n" +
+	"\x10PathOnMapRequest\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12-\n" +
+	"\aplayers\x18\x02 \x03(\v2\x13.findpath.v2.PlayerR\aplayers\x124\n" +
+	"\aoptions\x18\x03 \x01(\v2\x1a.findpath.v2.SearchOptionsR\aoptions\"X\n" +
+	"\fPathResponse\x12'\n" +
+	"\x05paths\x18\x01 \x03(\v2\x11.findpath.v2.PathR\x05paths\x12\x1f\n" +
+	"\vmap_version\x18\x02 \x01(\x04R\n" +
+	"mapVersion\";\n" +
+	"\x12PathStreamResponse\x12%\n" +
+	"\x04path\x18\x01 \x01(\v2\x11.findpath.v2.PathR\x04path\"\xfb\x02\n" +
+	"\rSearchOptions\x124\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2\x16.findpath.v2.AlgorithmR\talgorithm\x124\n" +
+	"\theuristic\x18\x02 \x01(\x0e2\x16.findpath.v2.HeuristicR\theuristic\x12)\n" +
+	"\x10heuristic_weight\x18\x03 \x01(\x01R\x0fheuristicWeight\x121\n" +
+	"\bmovement\x18\x04 \x01(\x0e2\x15.findpath.v2.MovementR\bmovement\x12+\n" +
+	"\x06limits\x18\x05 \x01(\v2\x13.findpath.v2.LimitsR\x06limits\x12\x14\n" +
+	"\x05stats\x18\x06 \x01(\bR\x05stats\x12#\n" +
+	"\rpartial_paths\x18\a \x01(\bR\fpartialPaths\x12\x17\n" +
+	"\ak_paths\x18\b \x01(\x05R\x06kPaths\x12\x1f\n" +
+	"\vmax_overlap\x18\t \x01(\x01R\n" +
+	"maxOverlap\"\x83\x01\n" +
+	"\x06Limits\x12%\n" +
+	"\x0emax_expansions\x18\x01 \x01(\x03R\rmaxExpansions\x12\x1d\n" +
+	"\n" +
+	"max_memory\x18\x02 \x01(\x03R\tmaxMemory\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\x9a\x04\n" +
+	"\x04Grid\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\x12\x14\n" +
+	"\x05cells\x18\x04 \x03(\x05R\x05cells\x127\n" +
+	"\vcost_layers\x18\x05 \x03(\v2\x16.findpath.v2.CostLayerR\n" +
+	"costLayers\x12H\n" +
+	"\rlayer_weights\x18\x06 \x03(\v2#.findpath.v2.Grid.LayerWeightsEntryR\flayerWeights\x12\x1b\n" +
+	"\tturn_cost\x18\a \x01(\x05R\bturnCost\x12\x1b\n" +
+	"\tmax_turns\x18\b \x01(\x05R\bmaxTurns\x12-\n" +
+	"\aportals\x18\t \x03(\v2\x13.findpath.v2.PortalR\aportals\x12,\n" +
+	"\x05exits\x18\n" +
+	" \x03(\v2\x16.findpath.v2.CellExitsR\x05exits\x12%\n" +
+	"\x04wrap\x18\v \x01(\x0e2\x11.findpath.v2.WrapR\x04wrap\x126\n" +
+	"\n" +
+	"connectors\x18\f \x03(\v2\x16.findpath.v2.ConnectorR\n" +
+	"connectors\x1a?\n" +
+	"\x11LayerWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\\\n" +
+	"\tConnector\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12'\n" +
+	"\x05cells\x18\x02 \x03(\v2\x11.findpath.v2.NodeR\x05cells\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x05R\x04cost\"f\n" +
+	"\x06Portal\x12%\n" +
+	"\x04from\x18\x01 \x01(\v2\x11.findpath.v2.NodeR\x04from\x12!\n" +
+	"\x02to\x18\x02 \x01(\v2\x11.findpath.v2.NodeR\x02to\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x05R\x04cost\"`\n" +
+	"\tCellExits\x12%\n" +
+	"\x04cell\x18\x01 \x01(\v2\x11.findpath.v2.NodeR\x04cell\x12,\n" +
+	"\x05allow\x18\x02 \x03(\x0e2\x16.findpath.v2.DirectionR\x05allow\"\x9a\x01\n" +
+	"\tCostLayer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x05cells\x18\x02 \x03(\v2\x15.findpath.v2.CellCostR\x05cells\x12\x14\n" +
+	"\x05dense\x18\x03 \x03(\x05R\x05dense\x126\n" +
+	"\asources\x18\x04 \x03(\v2\x1c.findpath.v2.InfluenceSourceR\asources\"E\n" +
+	"\bCellCost\x12%\n" +
+	"\x04cell\x18\x01 \x01(\v2\x11.findpath.v2.NodeR\x04cell\x12\x12\n" +
+	"\x04cost\x18\x02 \x01(\x05R\x04cost\"\xe0\x01\n" +
+	"\x0fInfluenceSource\x12%\n" +
+	"\x04cell\x18\x01 \x01(\v2\x11.findpath.v2.NodeR\x04cell\x12\x1a\n" +
+	"\bstrength\x18\x02 \x01(\x05R\bstrength\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x05R\x06radius\x12>\n" +
+	"\afalloff\x18\x04 \x01(\x0e2$.findpath.v2.InfluenceSource.FalloffR\afalloff\"2\n" +
+	"\aFalloff\x12\n" +
+	"\n" +
+	"\x06LINEAR\x10\x00\x12\f\n" +
+	"\bCONSTANT\x10\x01\x12\r\n" +
+	"\tQUADRATIC\x10\x02\"\x9a\x02\n" +
+	"\x06Player\x12'\n" +
+	"\x05start\x18\x01 \x01(\v2\x11.findpath.v2.NodeR\x05start\x12)\n" +
+	"\x06target\x18\x02 \x01(\v2\x11.findpath.v2.NodeR\x06target\x120\n" +
+	"\aheading\x18\x03 \x01(\x0e2\x16.findpath.v2.DirectionR\aheading\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12=\n" +
+	"\bmetadata\x18\x05 \x03(\v2!.findpath.v2.Player.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x04\n" +
+	"\x04Path\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12'\n" +
+	"\x05steps\x18\x02 \x03(\v2\x11.findpath.v2.NodeR\x05steps\x12\x14\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\x12.\n" +
+	"\x05stats\x18\x04 \x01(\v2\x18.findpath.v2.SearchStatsR\x05stats\x12*\n" +
+	"\x06routes\x18\x05 \x03(\v2\x12.findpath.v2.RouteR\x06routes\x12\x18\n" +
+	"\apartial\x18\x06 \x01(\bR\apartial\x120\n" +
+	"\x06status\x18\a \x01(\x0e2\x18.findpath.v2.Path.StatusR\x06status\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12;\n" +
+	"\bmetadata\x18\t \x03(\v2\x1f.findpath.v2.Path.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x06\n" +
+	"\x02OK\x10\x01\x12\v\n" +
+	"\aNO_PATH\x10\x02\x12\x11\n" +
+	"\rINVALID_START\x10\x03\x12\x12\n" +
+	"\x0eINVALID_TARGET\x10\x04\x12\r\n" +
+	"\tCANCELLED\x10\x05\x12\x13\n" +
+	"\x0fBUDGET_EXCEEDED\x10\x06\"D\n" +
+	"\x05Route\x12'\n" +
+	"\x05steps\x18\x01 \x03(\v2\x11.findpath.v2.NodeR\x05steps\x12\x12\n" +
+	"\x04cost\x18\x02 \x01(\x05R\x04cost\"\xc5\x01\n" +
+	"\vSearchStats\x12\x12\n" +
+	"\x04cost\x18\x01 \x01(\x05R\x04cost\x12%\n" +
+	"\x0enodes_expanded\x18\x02 \x01(\x03R\rnodesExpanded\x12'\n" +
+	"\x0fnodes_generated\x18\x03 \x01(\x03R\x0enodesGenerated\x12\x1b\n" +
+	"\tpeak_open\x18\x04 \x01(\x03R\bpeakOpen\x125\n" +
+	"\bduration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bduration\"0\n" +
+	"\x04Node\x12\f\n" +
+	"\x01y\x18\x01 \x01(\x05R\x01y\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x05R\x01z*z\n" +
+	"\tAlgorithm\x12\x19\n" +
+	"\x15ALGORITHM_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ALGORITHM_A_STAR\x10\x01\x12\x16\n" +
+	"\x12ALGORITHM_DIJKSTRA\x10\x02\x12\x11\n" +
+	"\rALGORITHM_BFS\x10\x03\x12\x11\n" +
+	"\rALGORITHM_JPS\x10\x04*R\n" +
+	"\tHeuristic\x12\x19\n" +
+	"\x15HEURISTIC_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12HEURISTIC_DISTANCE\x10\x01\x12\x12\n" +
+	"\x0eHEURISTIC_NONE\x10\x02*{\n" +
+	"\bMovement\x12\x18\n" +
+	"\x14MOVEMENT_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rMOVEMENT_GRID\x10\x01\x12\x14\n" +
+	"\x10MOVEMENT_VOXEL_6\x10\x02\x12\x15\n" +
+	"\x11MOVEMENT_VOXEL_18\x10\x03\x12\x15\n" +
+	"\x11MOVEMENT_VOXEL_26\x10\x04*L\n" +
+	"\x04Wrap\x12\r\n" +
+	"\tWRAP_NONE\x10\x00\x12\x13\n" +
+	"\x0fWRAP_HORIZONTAL\x10\x01\x12\x11\n" +
+	"\rWRAP_VERTICAL\x10\x02\x12\r\n" +
+	"\tWRAP_BOTH\x10\x03*n\n" +
+	"\tDirection\x12\x12\n" +
+	"\x0eDIRECTION_NONE\x10\x00\x12\x10\n" +
+	"\fDIRECTION_UP\x10\x01\x12\x12\n" +
+	"\x0eDIRECTION_DOWN\x10\x02\x12\x12\n" +
+	"\x0eDIRECTION_LEFT\x10\x03\x12\x13\n" +
+	"\x0fDIRECTION_RIGHT\x10\x042\xdb\x01\n" +
+	"\n" +
+	"PathFinder\x12;\n" +
+	"\x04Path\x12\x18.findpath.v2.PathRequest\x1a\x19.findpath.v2.PathResponse\x12I\n" +
+	"\n" +
+	"PathStream\x12\x18.findpath.v2.PathRequest\x1a\x1f.findpath.v2.PathStreamResponse0\x01\x12E\n" +
+	"\tPathOnMap\x12\x1d.findpath.v2.PathOnMapRequest\x1a\x19.findpath.v2.PathResponseB\x1fZ\x1dunomns.findpath.v2;findpathv2b\x06proto3"
+
+var (
+	file_findpath_v2_findpath_proto_rawDescOnce sync.Once
+	file_findpath_v2_findpath_proto_rawDescData []byte
+)
+
+func file_findpath_v2_findpath_proto_rawDescGZIP() []byte {
+	file_findpath_v2_findpath_proto_rawDescOnce.Do(func() {
+		file_findpath_v2_findpath_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_findpath_v2_findpath_proto_rawDesc), len(file_findpath_v2_findpath_proto_rawDesc)))
+	})
+	return file_findpath_v2_findpath_proto_rawDescData
+}
+
+var file_findpath_v2_findpath_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_findpath_v2_findpath_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_findpath_v2_findpath_proto_goTypes = []any{
+	(Algorithm)(0),               // 0: findpath.v2.Algorithm
+	(Heuristic)(0),               // 1: findpath.v2.Heuristic
+	(Movement)(0),                // 2: findpath.v2.Movement
+	(Wrap)(0),                    // 3: findpath.v2.Wrap
+	(Direction)(0),               // 4: findpath.v2.Direction
+	(InfluenceSource_Falloff)(0), // 5: findpath.v2.InfluenceSource.Falloff
+	(Path_Status)(0),             // 6: findpath.v2.Path.Status
+	(*PathRequest)(nil),          // 7: findpath.v2.PathRequest
+	(*PathOnMapRequest)(nil),     // 8: findpath.v2.PathOnMapRequest
+	(*PathResponse)(nil),         // 9: findpath.v2.PathResponse
+	(*PathStreamResponse)(nil),   // 10: findpath.v2.PathStreamResponse
+	(*SearchOptions)(nil),        // 11: findpath.v2.SearchOptions
+	(*Limits)(nil),               // 12: findpath.v2.Limits
+	(*Grid)(nil),                 // 13: findpath.v2.Grid
+	(*Connector)(nil),            // 14: findpath.v2.Connector
+	(*Portal)(nil),               // 15: findpath.v2.Portal
+	(*CellExits)(nil),            // 16: findpath.v2.CellExits
+	(*CostLayer)(nil),            // 17: findpath.v2.CostLayer
+	(*CellCost)(nil),             // 18: findpath.v2.CellCost
+	(*InfluenceSource)(nil),      // 19: findpath.v2.InfluenceSource
+	(*Player)(nil),               // 20: findpath.v2.Player
+	(*Path)(nil),                 // 21: findpath.v2.Path
+	(*Route)(nil),                // 22: findpath.v2.Route
+	(*SearchStats)(nil),          // 23: findpath.v2.SearchStats
+	(*Node)(nil),                 // 24: findpath.v2.Node
+	nil,                          // 25: findpath.v2.Grid.LayerWeightsEntry
+	nil,                          // 26: findpath.v2.Player.MetadataEntry
+	nil,                          // 27: findpath.v2.Path.MetadataEntry
+	(*durationpb.Duration)(nil),  // 28: google.protobuf.Duration
+}
+var file_findpath_v2_findpath_proto_depIdxs = []int32{
+	13, // 0: findpath.v2.PathRequest.grid:type_name -> findpath.v2.Grid
+	20, // 1: findpath.v2.PathRequest.players:type_name -> findpath.v2.Player
+	11, // 2: findpath.v2.PathRequest.options:type_name -> findpath.v2.SearchOptions
+	20, // 3: findpath.v2.PathOnMapRequest.players:type_name -> findpath.v2.Player
+	11, // 4: findpath.v2.PathOnMapRequest.options:type_name -> findpath.v2.SearchOptions
+	21, // 5: findpath.v2.PathResponse.paths:type_name -> findpath.v2.Path
+	21, // 6: findpath.v2.PathStreamResponse.path:type_name -> findpath.v2.Path
+	0,  // 7: findpath.v2.SearchOptions.algorithm:type_name -> findpath.v2.Algorithm
+	1,  // 8: findpath.v2.SearchOptions.heuristic:type_name -> findpath.v2.Heuristic
+	2,  // 9: findpath.v2.SearchOptions.movement:type_name -> findpath.v2.Movement
+	12, // 10: findpath.v2.SearchOptions.limits:type_name -> findpath.v2.Limits
+	28, // 11: findpath.v2.Limits.timeout:type_name -> google.protobuf.Duration
+	17, // 12: findpath.v2.Grid.cost_layers:type_name -> findpath.v2.CostLayer
+	25, // 13: findpath.v2.Grid.layer_weights:type_name -> findpath.v2.Grid.LayerWeightsEntry
+	15, // 14: findpath.v2.Grid.portals:type_name -> findpath.v2.Portal
+	16, // 15: findpath.v2.Grid.exits:type_name -> findpath.v2.CellExits
+	3,  // 16: findpath.v2.Grid.wrap:type_name -> findpath.v2.Wrap
+	14, // 17: findpath.v2.Grid.connectors:type_name -> findpath.v2.Connector
+	24, // 18: findpath.v2.Connector.cells:type_name -> findpath.v2.Node
+	24, // 19: findpath.v2.Portal.from:type_name -> findpath.v2.Node
+	24, // 20: findpath.v2.Portal.to:type_name -> findpath.v2.Node
+	24, // 21: findpath.v2.CellExits.cell:type_name -> findpath.v2.Node
+	4,  // 22: findpath.v2.CellExits.allow:type_name -> findpath.v2.Direction
+	18, // 23: findpath.v2.CostLayer.cells:type_name -> findpath.v2.CellCost
+	19, // 24: findpath.v2.CostLayer.sources:type_name -> findpath.v2.InfluenceSource
+	24, // 25: findpath.v2.CellCost.cell:type_name -> findpath.v2.Node
+	24, // 26: findpath.v2.InfluenceSource.cell:type_name -> findpath.v2.Node
+	5,  // 27: findpath.v2.InfluenceSource.falloff:type_name -> findpath.v2.InfluenceSource.Falloff
+	24, // 28: findpath.v2.Player.start:type_name -> findpath.v2.Node
+	24, // 29: findpath.v2.Player.target:type_name -> findpath.v2.Node
+	4,  // 30: findpath.v2.Player.heading:type_name -> findpath.v2.Direction
+	26, // 31: findpath.v2.Player.metadata:type_name -> findpath.v2.Player.MetadataEntry
+	24, // 32: findpath.v2.Path.steps:type_name -> findpath.v2.Node
+	23, // 33: findpath.v2.Path.stats:type_name -> findpath.v2.SearchStats
+	22, // 34: findpath.v2.Path.routes:type_name -> findpath.v2.Route
+	6,  // 35: findpath.v2.Path.status:type_name -> findpath.v2.Path.Status
+	27, // 36: findpath.v2.Path.metadata:type_name -> findpath.v2.Path.MetadataEntry
+	24, // 37: findpath.v2.Route.steps:type_name -> findpath.v2.Node
+	28, // 38: findpath.v2.SearchStats.duration:type_name -> google.protobuf.Duration
+	7,  // 39: findpath.v2.PathFinder.Path:input_type -> findpath.v2.PathRequest
+	7,  // 40: findpath.v2.PathFinder.PathStream:input_type -> findpath.v2.PathRequest
+	8,  // 41: findpath.v2.PathFinder.PathOnMap:input_type -> findpath.v2.PathOnMapRequest
+	9,  // 42: findpath.v2.PathFinder.Path:output_type -> findpath.v2.PathResponse
+	10, // 43: findpath.v2.PathFinder.PathStream:output_type -> findpath.v2.PathStreamResponse
+	9,  // 44: findpath.v2.PathFinder.PathOnMap:output_type -> findpath.v2.PathResponse
+	42, // [42:45] is the sub-list for method output_type
+	39, // [39:42] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_findpath_v2_findpath_proto_init() }
+func file_findpath_v2_findpath_proto_init() {
+	if File_findpath_v2_findpath_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_findpath_v2_findpath_proto_rawDesc), len(file_findpath_v2_findpath_proto_rawDesc)),
+			NumEnums:      7,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_findpath_v2_findpath_proto_goTypes,
+		DependencyIndexes: file_findpath_v2_findpath_proto_depIdxs,
+		EnumInfos:         file_findpath_v2_findpath_proto_enumTypes,
+		MessageInfos:      file_findpath_v2_findpath_proto_msgTypes,
+	}.Build()
+	File_findpath_v2_findpath_proto = out.File
+	file_findpath_v2_findpath_proto_goTypes = nil
+	file_findpath_v2_findpath_proto_depIdxs = nil
+}
@@ -0,0 +1,208 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: findpath/v2/findpath.proto
+
+// Version 2 lets clients choose how the server searches: algorithm, heuristic, movement and
+// limits. Version 1 is served next to it unchanged.
+
+package findpathv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PathFinder_Path_FullMethodName       = "/findpath.v2.PathFinder/Path"
+	PathFinder_PathStream_FullMethodName = "/findpath.v2.PathFinder/PathStream"
+	PathFinder_PathOnMap_FullMethodName  = "/findpath.v2.PathFinder/PathOnMap"
+)
+
+// PathFinderClient is the client API for PathFinder service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PathFinderClient interface {
+	Path(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*PathResponse, error)
+	// PathStream is Path with every player's path sent as soon as its search is done.
+	PathStream(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathStreamResponse], error)
+	// PathOnMap searches a map stored with the UploadMap call of version 1.
+	PathOnMap(ctx context.Context, in *PathOnMapRequest, opts ...grpc.CallOption) (*PathResponse, error)
+}
+
+type pathFinderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPathFinderClient(cc grpc.ClientConnInterface) PathFinderClient {
+	return &pathFinderClient{cc}
+}
+
+func (c *pathFinderClient) Path(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*PathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResponse)
+	err := c.cc.Invoke(ctx, PathFinder_Path_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pathFinderClient) PathStream(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PathFinder_ServiceDesc.Streams[0], PathFinder_PathStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PathRequest, PathStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_PathStreamClient = grpc.ServerStreamingClient[PathStreamResponse]
+
+func (c *pathFinderClient) PathOnMap(ctx context.Context, in *PathOnMapRequest, opts ...grpc.CallOption) (*PathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResponse)
+	err := c.cc.Invoke(ctx, PathFinder_PathOnMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PathFinderServer is the server API for PathFinder service.
+// All implementations must embed UnimplementedPathFinderServer
+// for forward compatibility.
+type PathFinderServer interface {
+	Path(context.Context, *PathRequest) (*PathResponse, error)
+	// PathStream is Path with every player's path sent as soon as its search is done.
+	PathStream(*PathRequest, grpc.ServerStreamingServer[PathStreamResponse]) error
+	// PathOnMap searches a map stored with the UploadMap call of version 1.
+	PathOnMap(context.Context, *PathOnMapRequest) (*PathResponse, error)
+	mustEmbedUnimplementedPathFinderServer()
+}
+
+// UnimplementedPathFinderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPathFinderServer struct{}
+
+func (UnimplementedPathFinderServer) Path(context.Context, *PathRequest) (*PathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Path not implemented")
+}
+func (UnimplementedPathFinderServer) PathStream(*PathRequest, grpc.ServerStreamingServer[PathStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PathStream not implemented")
+}
+func (UnimplementedPathFinderServer) PathOnMap(context.Context, *PathOnMapRequest) (*PathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PathOnMap not implemented")
+}
+func (UnimplementedPathFinderServer) mustEmbedUnimplementedPathFinderServer() {}
+func (UnimplementedPathFinderServer) testEmbeddedByValue()                    {}
+
+// UnsafePathFinderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PathFinderServer will
+// result in compilation errors.
+type UnsafePathFinderServer interface {
+	mustEmbedUnimplementedPathFinderServer()
+}
+
+func RegisterPathFinderServer(s grpc.ServiceRegistrar, srv PathFinderServer) {
+	// If the following call pancis, it indicates UnimplementedPathFinderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PathFinder_ServiceDesc, srv)
+}
+
+func _PathFinder_Path_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).Path(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_Path_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).Path(ctx, req.(*PathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PathFinder_PathStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PathRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PathFinderServer).PathStream(m, &grpc.GenericServerStream[PathRequest, PathStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PathFinder_PathStreamServer = grpc.ServerStreamingServer[PathStreamResponse]
+
+func _PathFinder_PathOnMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathOnMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PathFinderServer).PathOnMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PathFinder_PathOnMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PathFinderServer).PathOnMap(ctx, req.(*PathOnMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PathFinder_ServiceDesc is the grpc.ServiceDesc for PathFinder service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PathFinder_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "findpath.v2.PathFinder",
+	HandlerType: (*PathFinderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Path",
+			Handler:    _PathFinder_Path_Handler,
+		},
+		{
+			MethodName: "PathOnMap",
+			Handler:    _PathFinder_PathOnMap_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PathStream",
+			Handler:       _PathFinder_PathStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "findpath/v2/findpath.proto",
+}